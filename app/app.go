@@ -0,0 +1,243 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	grpcTool "github.com/justdomepaul/toolbox/grpc"
+	"github.com/justdomepaul/toolbox/restful"
+	"github.com/justdomepaul/toolbox/services"
+	"github.com/justdomepaul/toolbox/shutdown"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// PriorityDatabase shutdown hook priority of the last registered database cleanup,
+// earlier registered ones get higher priority, so cleanups run one by one in reverse registration order
+const PriorityDatabase = 100
+
+// Option interface
+type Option interface {
+	Apply(*App)
+}
+
+// WithSet method
+// use set instead of config.NewSet
+func WithSet(set config.Set) Option {
+	return withSet{set: set}
+}
+
+type withSet struct {
+	set config.Set
+}
+
+// Apply method
+func (w withSet) Apply(a *App) {
+	a.Set = w.set
+	a.setLoaded = true
+}
+
+// WithLogger method
+// use logger instead of building by config.Core
+func WithLogger(logger *zap.Logger) Option {
+	return withLogger{logger: logger}
+}
+
+type withLogger struct {
+	logger *zap.Logger
+}
+
+// Apply method
+func (w withLogger) Apply(a *App) {
+	a.Logger = w.logger
+}
+
+// WithHTTP method
+// builds gin engine by restful.NewGin, routes registers handlers,
+// guarder used when config.Server.JWTGuard enabled
+func WithHTTP(guarder *restful.JWTGuarder, routes ...func(*gin.Engine)) Option {
+	return withHTTP{guarder: guarder, routes: routes}
+}
+
+type withHTTP struct {
+	guarder *restful.JWTGuarder
+	routes  []func(*gin.Engine)
+}
+
+// Apply method
+func (w withHTTP) Apply(a *App) {
+	a.http = &w
+}
+
+// WithGRPC method
+// builds gRPC server by grpc.CreateServer, register registers services
+func WithGRPC(authenticate services.IAuthenticate, register ...func(*grpc.Server)) Option {
+	return withGRPC{authenticate: authenticate, register: register}
+}
+
+type withGRPC struct {
+	authenticate services.IAuthenticate
+	register     []func(*grpc.Server)
+}
+
+// Apply method
+func (w withGRPC) Apply(a *App) {
+	a.grpc = &w
+}
+
+// WithGRPCServerOptions method
+// options of grpc.CreateServer, used with WithGRPC
+func WithGRPCServerOptions(options ...grpcTool.ServerOption) Option {
+	return withGRPCServerOptions{options: options}
+}
+
+type withGRPCServerOptions struct {
+	options []grpcTool.ServerOption
+}
+
+// Apply method
+func (w withGRPCServerOptions) Apply(a *App) {
+	a.grpcServerOptions = append(a.grpcServerOptions, w.options...)
+}
+
+// WithShutdownOptions method
+func WithShutdownOptions(options ...shutdown.Option) Option {
+	return withShutdownOptions{options: options}
+}
+
+type withShutdownOptions struct {
+	options []shutdown.Option
+}
+
+// Apply method
+func (w withShutdownOptions) Apply(a *App) {
+	a.shutdownOptions = append(a.shutdownOptions, w.options...)
+}
+
+// App type
+type App struct {
+	Set      config.Set
+	Logger   *zap.Logger
+	Gin      *gin.Engine
+	GRPC     *grpc.Server
+	Shutdown *shutdown.Shutdown
+	// AllowOrigins CORS allowed origins of Gin, replaceable at runtime
+	AllowOrigins *restful.AllowOrigins
+
+	setLoaded         bool
+	http              *withHTTP
+	grpc              *withGRPC
+	grpcServerOptions []grpcTool.ServerOption
+	databases         []database
+	shutdownOptions   []shutdown.Option
+	result            chan error
+}
+
+type cleanup struct {
+	name string
+	fn   func(context.Context) error
+}
+
+// New method
+// reads config.Set, builds logger, enabled databases, HTTP and gRPC servers,
+// shutdown readiness is served on restful.ReadinessPath when WithHTTP used,
+// database cleanups are registered to Shutdown, already built databases are cleaned up when failed
+func New(options ...Option) (*App, error) {
+	a := &App{
+		result: make(chan error, 1),
+	}
+	for _, option := range options {
+		option.Apply(a)
+	}
+
+	if !a.setLoaded {
+		set, err := config.NewSet()
+		if err != nil {
+			return nil, err
+		}
+		a.Set = set
+	}
+	if a.Logger == nil {
+		logger, err := zapTool.NewLogger(a.Set.Core)
+		if err != nil {
+			return nil, err
+		}
+		a.Logger = logger
+	}
+	if a.Set.Core.LoggerMasking {
+		// reports of errorhandler, e.g. response of ErrGRPCConnection, are masked too
+		errorhandler.SetLogger(a.Logger)
+	}
+
+	cleanups := make([]cleanup, 0, len(a.databases))
+	rollback := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			if err := cleanups[i].fn(context.Background()); err != nil {
+				a.Logger.Warn("database cleanup failed", zap.String("system", "App"), zap.String("name", cleanups[i].name), zap.Error(err))
+			}
+		}
+	}
+	for _, db := range a.databases {
+		fn, err := db.build(a.Logger, a.Set)
+		if err != nil {
+			rollback()
+			return nil, err
+		}
+		if fn != nil {
+			cleanups = append(cleanups, cleanup{name: db.name, fn: fn})
+		}
+	}
+
+	shutdownOptions := []shutdown.Option{shutdown.WithServerTimeout(a.Set.Server.ServerTimeout)}
+	if a.http != nil {
+		a.AllowOrigins = restful.NewAllowOrigins(a.Set.Server)
+		engine, err := restful.NewGin(a.Set, a.Logger, restful.NewRender(), a.http.guarder, a.AllowOrigins)
+		if err != nil {
+			rollback()
+			return nil, err
+		}
+		for _, route := range a.http.routes {
+			route(engine)
+		}
+		a.Gin = engine
+		shutdownOptions = append(shutdownOptions, shutdown.WithHTTPServer(engine, a.Set.Server))
+	}
+	if a.grpc != nil {
+		server, err := grpcTool.CreateServerWithError(a.Logger, a.Set.GRPC, a.grpc.authenticate, a.grpcServerOptions...)
+		if err != nil {
+			rollback()
+			return nil, err
+		}
+		a.GRPC = server
+		for _, register := range a.grpc.register {
+			register(a.GRPC)
+		}
+		shutdownOptions = append(shutdownOptions, shutdown.WithGRPCServer(a.GRPC, a.Set.GRPC))
+	}
+	shutdownOptions = append(shutdownOptions, a.shutdownOptions...)
+	shutdownOptions = append(shutdownOptions, shutdown.WithDone(a.result))
+	a.Shutdown = shutdown.NewShutdown(shutdownOptions...)
+	if a.Gin != nil {
+		a.Gin.GET(restful.ReadinessPath, gin.WrapH(a.Shutdown.ReadinessHandler()))
+	}
+
+	for i, c := range cleanups {
+		a.Shutdown.Register(c.name, PriorityDatabase+len(cleanups)-1-i, c.fn)
+	}
+	return a, nil
+}
+
+// Run method
+// starts servers and blocks until shutdown completed, returns aggregated shutdown hook error,
+// when start failed, started servers and registered cleanups are still shut down
+func (a *App) Run() error {
+	if err := a.Shutdown.Start(); err != nil {
+		a.Shutdown.Shutdown()
+		return errors.Join(err, <-a.result)
+	}
+	a.Shutdown.Shutdown()
+	return <-a.result
+}
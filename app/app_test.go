@@ -0,0 +1,240 @@
+package app
+
+import (
+	"context"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/database/bunt"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	grpcTool "github.com/justdomepaul/toolbox/grpc"
+	"github.com/justdomepaul/toolbox/restful"
+	"github.com/justdomepaul/toolbox/shutdown"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type AppSuite struct {
+	suite.Suite
+	set config.Set
+}
+
+func (suite *AppSuite) SetupTest() {
+	set, err := config.NewSet()
+	suite.Require().NoError(err)
+	set.Server.Port = "0"
+	set.GRPC.Port = "0"
+	set.Server.ServerTimeout = time.Second
+	set.Server.JWTGuard = false
+	suite.set = set
+}
+
+func (suite *AppSuite) TestNew() {
+	a, err := New()
+	suite.NoError(err)
+	suite.NotNil(a.Logger)
+	suite.NotNil(a.Shutdown)
+	suite.Nil(a.Gin)
+	suite.Nil(a.GRPC)
+}
+
+func (suite *AppSuite) TestNewWithLogger() {
+	logger := zap.NewExample()
+	a, err := New(WithSet(suite.set), WithLogger(logger))
+	suite.NoError(err)
+	suite.Equal(logger, a.Logger)
+}
+
+func (suite *AppSuite) TestNewMaskingErrorhandler() {
+	defer errorhandler.SetLogger(zapTool.Logger)
+	suite.set.Core.LoggerMode = "customized"
+	suite.set.Core.LoggerMasking = true
+	path := filepath.Join(suite.T().TempDir(), "app.log")
+	suite.set.Core.LoggerSinks = []string{"file://" + path}
+	a, err := New(WithSet(suite.set))
+	suite.Require().NoError(err)
+
+	errorhandler.NewErrGRPCConnection(errors.New("got error"), map[string]string{"token": "value"}).Report("Error GRPC")
+	suite.NoError(a.Logger.Sync())
+	raw, err := os.ReadFile(path)
+	suite.NoError(err)
+	suite.Contains(string(raw), `"response":{"token":"`+zapTool.Mask+`"}`)
+}
+
+func (suite *AppSuite) TestNewWithServers() {
+	routed, registered := false, false
+	a, err := New(
+		WithSet(suite.set),
+		WithHTTP(nil, func(engine *gin.Engine) { routed = true }),
+		WithGRPC(nil, func(server *grpc.Server) { registered = true }),
+		WithGRPCServerOptions(grpcTool.WithReflection()),
+	)
+	suite.NoError(err)
+	suite.NotNil(a.Gin)
+	suite.True(a.AllowOrigins.Allow(suite.set.Server.AllowOrigins[0]))
+	suite.NotNil(a.GRPC)
+	suite.Contains(a.GRPC.GetServiceInfo(), "grpc.reflection.v1.ServerReflection")
+	suite.True(routed)
+	suite.True(registered)
+}
+
+func (suite *AppSuite) TestNewWithHTTPError() {
+	suite.set.Server.TrustedProxies = []string{"invalid proxy"}
+	closed := false
+	_, err := New(
+		WithSet(suite.set),
+		WithDatabase("mock", new(string), func(logger *zap.Logger, set config.Set) (string, func(context.Context) error, error) {
+			return "session", func(ctx context.Context) error {
+				closed = true
+				return nil
+			}, nil
+		}),
+		WithHTTP(nil),
+	)
+	suite.Error(err)
+	suite.True(closed)
+}
+
+func (suite *AppSuite) TestNewWithGRPCError() {
+	suite.set.GRPC.ServerTLSCert, suite.set.GRPC.ServerTLSKey = "cert", "key"
+	closed := false
+	_, err := New(
+		WithSet(suite.set),
+		WithDatabase("mock", new(string), func(logger *zap.Logger, set config.Set) (string, func(context.Context) error, error) {
+			return "session", func(ctx context.Context) error {
+				closed = true
+				return nil
+			}, nil
+		}),
+		WithGRPC(nil),
+	)
+	suite.ErrorContains(err, "gRPC server TLS certificate format error")
+	suite.True(closed)
+}
+
+func (suite *AppSuite) TestNewWithDatabase() {
+	session := ""
+	a, err := New(
+		WithSet(suite.set),
+		WithDatabase("mock", &session, func(logger *zap.Logger, set config.Set) (string, func(context.Context) error, error) {
+			return "session", func(ctx context.Context) error { return nil }, nil
+		}),
+	)
+	suite.NoError(err)
+	suite.Equal("session", session)
+	suite.NotNil(a)
+}
+
+func (suite *AppSuite) TestNewWithDatabaseError() {
+	closed := make([]string, 0)
+	_, err := New(
+		WithSet(suite.set),
+		WithDatabase("first", new(string), func(logger *zap.Logger, set config.Set) (string, func(context.Context) error, error) {
+			return "first", func(ctx context.Context) error {
+				closed = append(closed, "first")
+				return nil
+			}, nil
+		}),
+		WithDatabase("second", new(string), func(logger *zap.Logger, set config.Set) (string, func(context.Context) error, error) {
+			return "second", func(ctx context.Context) error { return errors.New("got error") }, nil
+		}),
+		WithDatabase("failed", new(string), func(logger *zap.Logger, set config.Set) (string, func(context.Context) error, error) {
+			return "", nil, errors.New("got error")
+		}),
+	)
+	suite.Error(err)
+	suite.Equal([]string{"first"}, closed)
+}
+
+func (suite *AppSuite) TestWithBunt() {
+	var session bunt.ISession
+	a, err := New(WithSet(suite.set), WithBunt(&session))
+	suite.NoError(err)
+	suite.NotNil(session)
+	suite.NotNil(a)
+}
+
+func (suite *AppSuite) TestRun() {
+	quit := make(chan os.Signal, 1)
+	var session bunt.ISession
+	a, err := New(
+		WithSet(suite.set),
+		WithBunt(&session),
+		WithDatabase("failed", new(string), func(logger *zap.Logger, set config.Set) (string, func(context.Context) error, error) {
+			return "", func(ctx context.Context) error { return errors.New("got error") }, nil
+		}),
+		WithHTTP(nil, func(engine *gin.Engine) {
+			engine.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+		}),
+		WithGRPC(nil),
+		WithShutdownOptions(shutdown.WithQuit(quit)),
+	)
+	suite.Require().NoError(err)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- a.Run()
+	}()
+	suite.Eventually(a.Shutdown.Ready, time.Second, 10*time.Millisecond)
+	resp, err := http.Get("http://" + a.Shutdown.HTTPAddr().String() + "/ping")
+	suite.Require().NoError(err)
+	suite.NoError(resp.Body.Close())
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	resp, err = http.Get("http://" + a.Shutdown.HTTPAddr().String() + restful.ReadinessPath)
+	suite.Require().NoError(err)
+	suite.NoError(resp.Body.Close())
+	suite.Equal(http.StatusOK, resp.StatusCode)
+
+	quit <- syscall.SIGTERM
+	err = <-result
+	suite.EqualError(err, "shutdown hook failed: got error")
+	suite.Len(a.Shutdown.Results(), 2)
+	suite.Equal("failed", a.Shutdown.Results()[0].Name)
+	suite.Equal(PriorityDatabase, a.Shutdown.Results()[0].Priority)
+	suite.Equal("bunt", a.Shutdown.Results()[1].Name)
+	suite.Equal(PriorityDatabase+1, a.Shutdown.Results()[1].Priority)
+}
+
+func (suite *AppSuite) TestRunStartError() {
+	lis, err := net.Listen("tcp", ":0")
+	suite.Require().NoError(err)
+	defer lis.Close()
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	suite.Require().NoError(err)
+	suite.set.GRPC.Port = port
+
+	cleaned := false
+	a, err := New(
+		WithSet(suite.set),
+		WithDatabase("failed", new(string), func(logger *zap.Logger, set config.Set) (string, func(context.Context) error, error) {
+			return "", func(ctx context.Context) error {
+				cleaned = true
+				return errors.New("got error")
+			}, nil
+		}),
+		WithHTTP(nil),
+		WithGRPC(nil),
+	)
+	suite.Require().NoError(err)
+
+	err = a.Run()
+	suite.ErrorContains(err, "listen gRPC server")
+	suite.ErrorContains(err, "shutdown hook failed: got error")
+	suite.True(cleaned)
+	suite.False(a.Shutdown.Ready())
+	_, err = http.Get("http://" + a.Shutdown.HTTPAddr().String() + "/")
+	suite.Error(err)
+}
+
+func TestAppSuite(t *testing.T) {
+	suite.Run(t, new(AppSuite))
+}
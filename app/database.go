@@ -0,0 +1,143 @@
+package app
+
+import (
+	"cloud.google.com/go/storage"
+	"context"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/database/bunt"
+	"github.com/justdomepaul/toolbox/database/cassandra"
+	"github.com/justdomepaul/toolbox/database/cloud"
+	"github.com/justdomepaul/toolbox/database/cockroach"
+	"github.com/justdomepaul/toolbox/database/firestore"
+	"github.com/justdomepaul/toolbox/database/gormspanner"
+	"github.com/justdomepaul/toolbox/database/loggingadmin"
+	"github.com/justdomepaul/toolbox/database/mongo"
+	"github.com/justdomepaul/toolbox/database/postgres"
+	"github.com/justdomepaul/toolbox/database/postgresql"
+	"github.com/justdomepaul/toolbox/database/pubsub"
+	"github.com/justdomepaul/toolbox/database/redis"
+	"github.com/justdomepaul/toolbox/database/spanner"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type database struct {
+	name  string
+	build func(logger *zap.Logger, set config.Set) (func(context.Context) error, error)
+}
+
+// WithDatabase method
+// enables database built by fn, session is stored into target and cleanup registered to Shutdown
+func WithDatabase[T any](name string, target *T, fn func(logger *zap.Logger, set config.Set) (T, func(context.Context) error, error)) Option {
+	return withDatabase{database: database{
+		name: name,
+		build: func(logger *zap.Logger, set config.Set) (func(context.Context) error, error) {
+			session, cleanup, err := fn(logger, set)
+			if err != nil {
+				return nil, err
+			}
+			*target = session
+			return cleanup, nil
+		},
+	}}
+}
+
+type withDatabase struct {
+	database database
+}
+
+// Apply method
+func (w withDatabase) Apply(a *App) {
+	a.databases = append(a.databases, w.database)
+}
+
+// WithBunt method
+func WithBunt(target *bunt.ISession) Option {
+	return WithDatabase("bunt", target, func(logger *zap.Logger, set config.Set) (bunt.ISession, func(context.Context) error, error) {
+		return bunt.NewExtendBuntDatabase(logger)
+	})
+}
+
+// WithCassandra method
+func WithCassandra(target *cassandra.ISession) Option {
+	return WithDatabase("cassandra", target, func(logger *zap.Logger, set config.Set) (cassandra.ISession, func(context.Context) error, error) {
+		return cassandra.NewExtendCassandraDatabase(logger, set.Cassandra)
+	})
+}
+
+// WithStorage method
+func WithStorage(target **storage.Client) Option {
+	return WithDatabase("storage", target, func(logger *zap.Logger, set config.Set) (*storage.Client, func(context.Context) error, error) {
+		return cloud.NewExtendStorageDatabase(logger, set.Cloud)
+	})
+}
+
+// WithCockroach method
+func WithCockroach(target *cockroach.ISession) Option {
+	return WithDatabase("cockroach", target, func(logger *zap.Logger, set config.Set) (cockroach.ISession, func(context.Context) error, error) {
+		return cockroach.NewExtendCockroachDatabase(logger, set.Cockroach)
+	})
+}
+
+// WithFirestore method
+func WithFirestore(target *firestore.ISession) Option {
+	return WithDatabase("firestore", target, func(logger *zap.Logger, set config.Set) (firestore.ISession, func(context.Context) error, error) {
+		return firestore.NewExtendFirestoreDatabase(logger, set.Firestore)
+	})
+}
+
+// WithGormSpanner method
+func WithGormSpanner(target **gorm.DB) Option {
+	return WithDatabase("gormspanner", target, func(logger *zap.Logger, set config.Set) (*gorm.DB, func(context.Context) error, error) {
+		return gormspanner.NewExtendGormSpannerDatabase(logger, set.Spanner)
+	})
+}
+
+// WithLoggingAdmin method
+func WithLoggingAdmin(target *loggingadmin.ISession) Option {
+	return WithDatabase("loggingadmin", target, func(logger *zap.Logger, set config.Set) (loggingadmin.ISession, func(context.Context) error, error) {
+		return loggingadmin.NewExtendLoggingAdmin(logger, set.Spanner)
+	})
+}
+
+// WithMongo method
+func WithMongo(target *mongo.ISession) Option {
+	return WithDatabase("mongo", target, func(logger *zap.Logger, set config.Set) (mongo.ISession, func(context.Context) error, error) {
+		return mongo.NewExtendMongoDatabase(logger, set.Mongo)
+	})
+}
+
+// WithPostgres method
+func WithPostgres(target *postgres.ISession) Option {
+	return WithDatabase("postgres", target, func(logger *zap.Logger, set config.Set) (postgres.ISession, func(context.Context) error, error) {
+		return postgres.NewExtendPostgresDatabase(logger, set.Postgres)
+	})
+}
+
+// WithPostgresql method
+func WithPostgresql(target *postgresql.ISession) Option {
+	return WithDatabase("postgresql", target, func(logger *zap.Logger, set config.Set) (postgresql.ISession, func(context.Context) error, error) {
+		return postgresql.NewExtendPostgresqlDatabase(logger, set.Postgresql)
+	})
+}
+
+// WithPubSub method
+func WithPubSub(target *pubsub.ISession) Option {
+	return WithDatabase("pubsub", target, func(logger *zap.Logger, set config.Set) (pubsub.ISession, func(context.Context) error, error) {
+		return pubsub.NewExtendPubSubDatabase(logger, set.PubSub)
+	})
+}
+
+// WithRedis method
+func WithRedis(target *redis.ISession) Option {
+	return WithDatabase("redis", target, func(logger *zap.Logger, set config.Set) (redis.ISession, func(context.Context) error, error) {
+		return redis.NewExtendRedisDatabase(logger, set.Redis)
+	})
+}
+
+// WithSpanner method
+func WithSpanner(target *spanner.ISession) Option {
+	return WithDatabase("spanner", target, func(logger *zap.Logger, set config.Set) (spanner.ISession, func(context.Context) error, error) {
+		return spanner.NewExtendSpannerDatabase(logger, set.Spanner)
+	})
+}
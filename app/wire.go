@@ -0,0 +1,177 @@
+package app
+
+import (
+	"cloud.google.com/go/storage"
+	"context"
+	"github.com/google/wire"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/database/bunt"
+	"github.com/justdomepaul/toolbox/database/cassandra"
+	"github.com/justdomepaul/toolbox/database/cloud"
+	"github.com/justdomepaul/toolbox/database/cockroach"
+	"github.com/justdomepaul/toolbox/database/firestore"
+	"github.com/justdomepaul/toolbox/database/gormspanner"
+	"github.com/justdomepaul/toolbox/database/loggingadmin"
+	"github.com/justdomepaul/toolbox/database/mongo"
+	"github.com/justdomepaul/toolbox/database/postgres"
+	"github.com/justdomepaul/toolbox/database/postgresql"
+	"github.com/justdomepaul/toolbox/database/pubsub"
+	"github.com/justdomepaul/toolbox/database/redis"
+	"github.com/justdomepaul/toolbox/database/spanner"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	grpcTool "github.com/justdomepaul/toolbox/grpc"
+	"github.com/justdomepaul/toolbox/restful"
+	"github.com/justdomepaul/toolbox/services"
+	"github.com/justdomepaul/toolbox/shutdown"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+// google/wire provider sets, e.g.
+//
+//	wire.Build(app.ConfigSet, app.LoggerSet, app.RedisSet, NewService)
+var (
+	// ConfigSet provides config.Set and every config section
+	ConfigSet = wire.NewSet(
+		config.NewSet,
+		config.NewCassandra,
+		config.NewCloud,
+		config.NewCockroach,
+		config.NewCore,
+		config.NewFirebase,
+		config.NewFirestore,
+		config.NewGRPC,
+		config.NewJWT,
+		config.NewMongo,
+		config.NewPubSub,
+		config.NewPostgres,
+		config.NewPostgresql,
+		config.NewRedis,
+		config.NewServer,
+		config.NewSpanner,
+	)
+	// LoggerSet provides *zap.Logger, needs config.Core
+	LoggerSet = wire.NewSet(zapTool.NewLogger)
+	// RestfulSet provides *gin.Engine and *restful.AllowOrigins, needs config.Set, *zap.Logger and restful.GuarderValidator
+	RestfulSet = wire.NewSet(restful.NewRender, restful.NewJWTGuarder, restful.NewAllowOrigins, restful.NewGin)
+	// GRPCSet provides *grpc.Server, needs *zap.Logger, config.GRPC and services.IAuthenticate
+	GRPCSet = wire.NewSet(NewGRPCServer)
+	// ShutdownSet provides *shutdown.Shutdown, needs config.Server
+	ShutdownSet = wire.NewSet(NewShutdown)
+
+	BuntSet         = wire.NewSet(NewBunt)
+	CassandraSet    = wire.NewSet(NewCassandra)
+	CloudSet        = wire.NewSet(NewStorage)
+	CockroachSet    = wire.NewSet(NewCockroach)
+	FirestoreSet    = wire.NewSet(NewFirestore)
+	GormSpannerSet  = wire.NewSet(NewGormSpanner)
+	LoggingAdminSet = wire.NewSet(NewLoggingAdmin)
+	MongoSet        = wire.NewSet(NewMongo)
+	PostgresSet     = wire.NewSet(NewPostgres)
+	PostgresqlSet   = wire.NewSet(NewPostgresql)
+	PubSubSet       = wire.NewSet(NewPubSub)
+	RedisSet        = wire.NewSet(NewRedis)
+	SpannerSet      = wire.NewSet(NewSpanner)
+)
+
+// NewShutdown method
+// wire friendly shutdown.NewShutdown, uses option.ServerTimeout
+func NewShutdown(option config.Server) *shutdown.Shutdown {
+	return shutdown.NewShutdown(shutdown.WithServerTimeout(option.ServerTimeout))
+}
+
+// NewGRPCServer method
+// wire friendly grpc.CreateServerWithError without server options, wire cannot provide variadic arguments
+func NewGRPCServer(logger *zap.Logger, option config.GRPC, authenticateService services.IAuthenticate) (*grpc.Server, error) {
+	return grpcTool.CreateServerWithError(logger, option, authenticateService)
+}
+
+// NewBunt method
+// wire friendly bunt.NewExtendBuntDatabase
+func NewBunt(logger *zap.Logger) (bunt.ISession, func(), error) {
+	return wireCleanup(bunt.NewExtendBuntDatabase(logger))
+}
+
+// NewCassandra method
+// wire friendly cassandra.NewExtendCassandraDatabase
+func NewCassandra(logger *zap.Logger, opt config.Cassandra) (cassandra.ISession, func(), error) {
+	return wireCleanup(cassandra.NewExtendCassandraDatabase(logger, opt))
+}
+
+// NewStorage method
+// wire friendly cloud.NewExtendStorageDatabase
+func NewStorage(logger *zap.Logger, opt config.Cloud) (*storage.Client, func(), error) {
+	return wireCleanup(cloud.NewExtendStorageDatabase(logger, opt))
+}
+
+// NewCockroach method
+// wire friendly cockroach.NewExtendCockroachDatabase
+func NewCockroach(logger *zap.Logger, opt config.Cockroach) (cockroach.ISession, func(), error) {
+	return wireCleanup(cockroach.NewExtendCockroachDatabase(logger, opt))
+}
+
+// NewFirestore method
+// wire friendly firestore.NewExtendFirestoreDatabase
+func NewFirestore(logger *zap.Logger, opt config.Firestore) (firestore.ISession, func(), error) {
+	return wireCleanup(firestore.NewExtendFirestoreDatabase(logger, opt))
+}
+
+// NewGormSpanner method
+// wire friendly gormspanner.NewExtendGormSpannerDatabase
+func NewGormSpanner(logger *zap.Logger, opt config.Spanner) (*gorm.DB, func(), error) {
+	return wireCleanup(gormspanner.NewExtendGormSpannerDatabase(logger, opt))
+}
+
+// NewLoggingAdmin method
+// wire friendly loggingadmin.NewExtendLoggingAdmin
+func NewLoggingAdmin(logger *zap.Logger, opt config.Spanner) (loggingadmin.ISession, func(), error) {
+	return wireCleanup(loggingadmin.NewExtendLoggingAdmin(logger, opt))
+}
+
+// NewMongo method
+// wire friendly mongo.NewExtendMongoDatabase
+func NewMongo(logger *zap.Logger, opt config.Mongo) (mongo.ISession, func(), error) {
+	return wireCleanup(mongo.NewExtendMongoDatabase(logger, opt))
+}
+
+// NewPostgres method
+// wire friendly postgres.NewExtendPostgresDatabase
+func NewPostgres(logger *zap.Logger, opt config.Postgres) (postgres.ISession, func(), error) {
+	return wireCleanup(postgres.NewExtendPostgresDatabase(logger, opt))
+}
+
+// NewPostgresql method
+// wire friendly postgresql.NewExtendPostgresqlDatabase
+func NewPostgresql(logger *zap.Logger, opt config.Postgresql) (postgresql.ISession, func(), error) {
+	return wireCleanup(postgresql.NewExtendPostgresqlDatabase(logger, opt))
+}
+
+// NewPubSub method
+// wire friendly pubsub.NewExtendPubSubDatabase
+func NewPubSub(logger *zap.Logger, opt config.PubSub) (pubsub.ISession, func(), error) {
+	return wireCleanup(pubsub.NewExtendPubSubDatabase(logger, opt))
+}
+
+// NewRedis method
+// wire friendly redis.NewExtendRedisDatabase
+func NewRedis(logger *zap.Logger, opt config.Redis) (redis.ISession, func(), error) {
+	return wireCleanup(redis.NewExtendRedisDatabase(logger, opt))
+}
+
+// NewSpanner method
+// wire friendly spanner.NewExtendSpannerDatabase
+func NewSpanner(logger *zap.Logger, opt config.Spanner) (spanner.ISession, func(), error) {
+	return wireCleanup(spanner.NewExtendSpannerDatabase(logger, opt))
+}
+
+// wireCleanup adapts error returning cleanup to wire cleanup, cleanup error reported by errorhandler
+func wireCleanup[T any](session T, cleanup func(context.Context) error, err error) (T, func(), error) {
+	if err != nil {
+		return session, nil, err
+	}
+	return session, func() {
+		errorhandler.Report(cleanup(context.Background()), "Core", "")
+	}, nil
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"cloud.google.com/go/storage"
+	"context"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/database/bunt"
+	"github.com/justdomepaul/toolbox/database/cassandra"
+	"github.com/justdomepaul/toolbox/database/cockroach"
+	"github.com/justdomepaul/toolbox/database/firestore"
+	"github.com/justdomepaul/toolbox/database/loggingadmin"
+	"github.com/justdomepaul/toolbox/database/mongo"
+	"github.com/justdomepaul/toolbox/database/postgres"
+	"github.com/justdomepaul/toolbox/database/postgresql"
+	"github.com/justdomepaul/toolbox/database/pubsub"
+	"github.com/justdomepaul/toolbox/database/redis"
+	"github.com/justdomepaul/toolbox/database/spanner"
+	"github.com/justdomepaul/toolbox/restful"
+	"github.com/justdomepaul/toolbox/services"
+	"github.com/justdomepaul/toolbox/shutdown"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// wire providers must return T, (T, error) or (T, func(), error), checked at compile time
+var (
+	_ func() (config.Set, error)                                                                                      = config.NewSet
+	_ func(config.Core) (*zap.Logger, error)                                                                          = zapTool.NewLogger
+	_ func() *restful.Render                                                                                          = restful.NewRender
+	_ func(config.JWT, restful.GuarderValidator) *restful.JWTGuarder                                                  = restful.NewJWTGuarder
+	_ func(config.Server) *restful.AllowOrigins                                                                       = restful.NewAllowOrigins
+	_ func(config.Set, *zap.Logger, *restful.Render, *restful.JWTGuarder, *restful.AllowOrigins) (*gin.Engine, error) = restful.NewGin
+	_ func(config.Server) *shutdown.Shutdown                                                                          = NewShutdown
+	_ func(*zap.Logger, config.GRPC, services.IAuthenticate) (*grpc.Server, error)                                    = NewGRPCServer
+	_ func(*zap.Logger) (bunt.ISession, func(), error)                                                                = NewBunt
+	_ func(*zap.Logger, config.Cassandra) (cassandra.ISession, func(), error)                                         = NewCassandra
+	_ func(*zap.Logger, config.Cloud) (*storage.Client, func(), error)                                                = NewStorage
+	_ func(*zap.Logger, config.Cockroach) (cockroach.ISession, func(), error)                                         = NewCockroach
+	_ func(*zap.Logger, config.Firestore) (firestore.ISession, func(), error)                                         = NewFirestore
+	_ func(*zap.Logger, config.Spanner) (*gorm.DB, func(), error)                                                     = NewGormSpanner
+	_ func(*zap.Logger, config.Spanner) (loggingadmin.ISession, func(), error)                                        = NewLoggingAdmin
+	_ func(*zap.Logger, config.Mongo) (mongo.ISession, func(), error)                                                 = NewMongo
+	_ func(*zap.Logger, config.Postgres) (postgres.ISession, func(), error)                                           = NewPostgres
+	_ func(*zap.Logger, config.Postgresql) (postgresql.ISession, func(), error)                                       = NewPostgresql
+	_ func(*zap.Logger, config.PubSub) (pubsub.ISession, func(), error)                                               = NewPubSub
+	_ func(*zap.Logger, config.Redis) (redis.ISession, func(), error)                                                 = NewRedis
+	_ func(*zap.Logger, config.Spanner) (spanner.ISession, func(), error)                                             = NewSpanner
+)
+
+type injected struct {
+	Session  bunt.ISession
+	Shutdown *shutdown.Shutdown
+}
+
+// initializeInjected expanded as wire generates for
+//
+//	wire.Build(ConfigSet, LoggerSet, BuntSet, ShutdownSet, wire.Struct(new(injected), "*"))
+func initializeInjected() (*injected, func(), error) {
+	set, err := config.NewSet()
+	if err != nil {
+		return nil, nil, err
+	}
+	core := config.NewCore(set)
+	logger, err := zapTool.NewLogger(core)
+	if err != nil {
+		return nil, nil, err
+	}
+	session, cleanup, err := NewBunt(logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	server := config.NewServer(set)
+	shutdownShutdown := NewShutdown(server)
+	return &injected{
+		Session:  session,
+		Shutdown: shutdownShutdown,
+	}, func() {
+		cleanup()
+	}, nil
+}
+
+type WireSuite struct {
+	suite.Suite
+}
+
+func (suite *WireSuite) TestNewShutdown() {
+	suite.Equal("*shutdown.Shutdown", reflect.TypeOf(NewShutdown(config.Server{ServerTimeout: time.Second})).String())
+}
+
+func (suite *WireSuite) TestNewGRPCServer() {
+	server, err := NewGRPCServer(zap.NewExample(), config.GRPC{}, nil)
+	suite.NoError(err)
+	suite.NotNil(server)
+
+	_, err = NewGRPCServer(zap.NewExample(), config.GRPC{ServerTLSCert: "cert", ServerTLSKey: "key"}, nil)
+	suite.Error(err)
+}
+
+func (suite *WireSuite) TestNewBunt() {
+	session, cleanup, err := NewBunt(zap.NewExample())
+	suite.NoError(err)
+	suite.NotNil(session)
+	suite.NotPanics(cleanup)
+}
+
+func (suite *WireSuite) TestWireCleanup() {
+	called := false
+	session, cleanup, err := wireCleanup("session", func(ctx context.Context) error {
+		called = true
+		return errors.New("got error")
+	}, nil)
+	suite.NoError(err)
+	suite.Equal("session", session)
+	suite.NotPanics(cleanup)
+	suite.True(called)
+
+	_, cleanup, err = wireCleanup("", nil, errors.New("got error"))
+	suite.Error(err)
+	suite.Nil(cleanup)
+}
+
+func (suite *WireSuite) TestInjector() {
+	result, cleanup, err := initializeInjected()
+	suite.Require().NoError(err)
+	suite.NotNil(result.Session)
+	suite.NotNil(result.Shutdown)
+	suite.NotPanics(cleanup)
+}
+
+func TestWireSuite(t *testing.T) {
+	suite.Run(t, new(WireSuite))
+}
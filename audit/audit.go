@@ -0,0 +1,286 @@
+package audit
+
+import (
+	"context"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/peer"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	now = time.Now
+)
+
+// Decision type
+type Decision string
+
+const (
+	// DecisionAllow authenticated or authorized
+	DecisionAllow Decision = "allow"
+	// DecisionDeny rejected, Reason describes why
+	DecisionDeny Decision = "deny"
+	// DecisionSkip skipped by allowed list
+	DecisionSkip Decision = "skip"
+)
+
+// Event type
+// security decision of authentication or authorization
+type Event struct {
+	Time      time.Time `json:"time" db:"time"`
+	ClientID  string    `json:"client_id,omitempty" db:"client_id"`
+	Method    string    `json:"method" db:"method"` // gRPC full method or HTTP method
+	Path      string    `json:"path,omitempty" db:"path"`
+	Decision  Decision  `json:"decision" db:"decision"`
+	Reason    string    `json:"reason,omitempty" db:"reason"`
+	TokenID   string    `json:"token_id,omitempty" db:"token_id"` // jti of token
+	SourceIP  string    `json:"source_ip,omitempty" db:"source_ip"`
+	RequestID string    `json:"request_id,omitempty" db:"request_id"`
+}
+
+// MarshalLogObject method
+func (e Event) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddTime("time", e.Time)
+	enc.AddString("client_id", e.ClientID)
+	enc.AddString("method", e.Method)
+	enc.AddString("path", e.Path)
+	enc.AddString("decision", string(e.Decision))
+	enc.AddString("reason", e.Reason)
+	enc.AddString("token_id", e.TokenID)
+	enc.AddString("source_ip", e.SourceIP)
+	enc.AddString("request_id", e.RequestID)
+	return nil
+}
+
+// Unmasked method
+// implements zap.Unmasked, token_id and client_id are kept by masking loggers
+func (e Event) Unmasked() {}
+
+// Sink interface
+// writes batch of events, e.g. NewZapSink, NewPostgresSink, NewPubSubSink
+type Sink interface {
+	Write(ctx context.Context, events []Event) error
+}
+
+// IAuditor interface
+type IAuditor interface {
+	Record(event Event)
+}
+
+// Option interface
+type Option interface {
+	Apply(*Auditor)
+}
+
+// WithBufferSize method
+// events buffered before written, events recorded when buffer full are dropped, default 1024
+func WithBufferSize(size int) Option {
+	return withBufferSize{size: size}
+}
+
+type withBufferSize struct {
+	size int
+}
+
+// Apply method
+func (w withBufferSize) Apply(a *Auditor) {
+	a.bufferSize = w.size
+}
+
+// WithBatchSize method
+// max events per Sink.Write, default 100
+func WithBatchSize(size int) Option {
+	return withBatchSize{size: size}
+}
+
+type withBatchSize struct {
+	size int
+}
+
+// Apply method
+func (w withBatchSize) Apply(a *Auditor) {
+	a.batchSize = w.size
+}
+
+// WithFlushInterval method
+// buffered events are written at least every interval, default 1s
+func WithFlushInterval(interval time.Duration) Option {
+	return withFlushInterval{interval: interval}
+}
+
+type withFlushInterval struct {
+	interval time.Duration
+}
+
+// Apply method
+func (w withFlushInterval) Apply(a *Auditor) {
+	a.interval = w.interval
+}
+
+// WithWriteTimeout method
+// timeout of each Sink.Write, default 5s
+func WithWriteTimeout(timeout time.Duration) Option {
+	return withWriteTimeout{timeout: timeout}
+}
+
+type withWriteTimeout struct {
+	timeout time.Duration
+}
+
+// Apply method
+func (w withWriteTimeout) Apply(a *Auditor) {
+	a.timeout = w.timeout
+}
+
+// WithErrorHandler method
+// receives Sink.Write error with events not written
+func WithErrorHandler(fn func(err error, events []Event)) Option {
+	return withErrorHandler{fn: fn}
+}
+
+type withErrorHandler struct {
+	fn func(err error, events []Event)
+}
+
+// Apply method
+func (w withErrorHandler) Apply(a *Auditor) {
+	a.errorHandler = w.fn
+}
+
+// Auditor type
+// records events without blocking, writes them to Sink in background by batch
+type Auditor struct {
+	sink         Sink
+	bufferSize   int
+	batchSize    int
+	interval     time.Duration
+	timeout      time.Duration
+	errorHandler func(err error, events []Event)
+
+	mu      sync.RWMutex
+	closed  bool
+	events  chan Event
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+// NewAuditor method
+// starts background writer, Close flushes buffered events
+func NewAuditor(sink Sink, options ...Option) *Auditor {
+	a := &Auditor{
+		sink:         sink,
+		bufferSize:   1024,
+		batchSize:    100,
+		interval:     time.Second,
+		timeout:      5 * time.Second,
+		errorHandler: func(err error, events []Event) {},
+		done:         make(chan struct{}),
+	}
+	for _, option := range options {
+		option.Apply(a)
+	}
+	if a.batchSize <= 0 {
+		a.batchSize = 1
+	}
+	a.events = make(chan Event, a.bufferSize)
+	go a.run()
+	return a
+}
+
+// Record method
+// never blocks, event is dropped when buffer full or Auditor closed
+func (a *Auditor) Record(event Event) {
+	if event.Time.IsZero() {
+		event.Time = now()
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		a.dropped.Add(1)
+		return
+	}
+	select {
+	case a.events <- event:
+	default:
+		a.dropped.Add(1)
+	}
+}
+
+// Dropped method
+// count of events dropped by Record
+func (a *Auditor) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// Close method
+// stops recording and waits buffered events written until ctx done
+func (a *Auditor) Close(ctx context.Context) error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.events)
+	}
+	a.mu.Unlock()
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *Auditor) run() {
+	defer close(a.done)
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	batch := make([]Event, 0, a.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		a.write(batch)
+		batch = make([]Event, 0, a.batchSize)
+	}
+	for {
+		select {
+		case event, ok := <-a.events:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= a.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (a *Auditor) write(events []Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+	if err := a.sink.Write(ctx, events); err != nil {
+		a.errorHandler(err, events)
+	}
+}
+
+// PeerIP method
+// IP of gRPC peer of ctx, empty when unknown
+func PeerIP(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/peer"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testSink struct {
+	mu      sync.Mutex
+	batches [][]Event
+	err     error
+	block   chan struct{}
+}
+
+func (t *testSink) Write(ctx context.Context, events []Event) error {
+	if t.block != nil {
+		<-t.block
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.batches = append(t.batches, events)
+	return t.err
+}
+
+func (t *testSink) Batches() [][]Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.batches
+}
+
+type AuditorSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (suite *AuditorSuite) SetupTest() {
+	suite.now = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+}
+
+func (suite *AuditorSuite) TestBatch() {
+	defer gostub.StubFunc(&now, suite.now).Reset()
+	sink := &testSink{}
+	auditor := NewAuditor(sink, WithBatchSize(2), WithFlushInterval(time.Hour))
+	for _, method := range []string{"a", "b", "c"} {
+		auditor.Record(Event{Method: method, Decision: DecisionAllow})
+	}
+	suite.Eventually(func() bool { return len(sink.Batches()) == 1 }, time.Second, time.Millisecond)
+	suite.NoError(auditor.Close(context.Background()))
+
+	suite.Equal([][]Event{
+		{{Time: suite.now, Method: "a", Decision: DecisionAllow}, {Time: suite.now, Method: "b", Decision: DecisionAllow}},
+		{{Time: suite.now, Method: "c", Decision: DecisionAllow}},
+	}, sink.Batches())
+	suite.Zero(auditor.Dropped())
+}
+
+func (suite *AuditorSuite) TestFlushInterval() {
+	sink := &testSink{}
+	auditor := NewAuditor(sink, WithFlushInterval(10*time.Millisecond))
+	defer auditor.Close(context.Background())
+	auditor.Record(Event{Time: suite.now, Method: "a"})
+	suite.Eventually(func() bool { return len(sink.Batches()) == 1 }, time.Second, time.Millisecond)
+	suite.Equal([]Event{{Time: suite.now, Method: "a"}}, sink.Batches()[0])
+}
+
+func (suite *AuditorSuite) TestDropped() {
+	sink := &testSink{block: make(chan struct{})}
+	auditor := NewAuditor(sink, WithBufferSize(1), WithBatchSize(1))
+	auditor.Record(Event{Method: "a"})
+	suite.Eventually(func() bool { return len(auditor.events) == 0 }, time.Second, time.Millisecond)
+	auditor.Record(Event{Method: "b"})
+	auditor.Record(Event{Method: "c"})
+	suite.Equal(uint64(1), auditor.Dropped())
+
+	close(sink.block)
+	suite.NoError(auditor.Close(context.Background()))
+	auditor.Record(Event{Method: "d"})
+	suite.Equal(uint64(2), auditor.Dropped())
+	suite.Len(sink.Batches(), 2)
+	suite.NoError(auditor.Close(context.Background()))
+}
+
+func (suite *AuditorSuite) TestCloseTimeout() {
+	sink := &testSink{block: make(chan struct{})}
+	defer close(sink.block)
+	auditor := NewAuditor(sink)
+	auditor.Record(Event{Method: "a"})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	suite.ErrorIs(auditor.Close(ctx), context.DeadlineExceeded)
+}
+
+func (suite *AuditorSuite) TestErrorHandler() {
+	sink := &testSink{err: errors.New("write error")}
+	var (
+		mu     sync.Mutex
+		failed []Event
+	)
+	auditor := NewAuditor(sink, WithBatchSize(0), WithWriteTimeout(time.Second), WithErrorHandler(func(err error, events []Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		suite.EqualError(err, "write error")
+		failed = append(failed, events...)
+	}))
+	auditor.Record(Event{Time: suite.now, Method: "a"})
+	suite.NoError(auditor.Close(context.Background()))
+	mu.Lock()
+	defer mu.Unlock()
+	suite.Equal([]Event{{Time: suite.now, Method: "a"}}, failed)
+}
+
+func (suite *AuditorSuite) TestPeerIP() {
+	suite.Equal("", PeerIP(nil))
+	suite.Equal("", PeerIP(context.Background()))
+	suite.Equal("10.0.0.1", PeerIP(peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})))
+	suite.Equal("bufconn", PeerIP(peer.NewContext(context.Background(), &peer.Peer{Addr: testAddr("bufconn")})))
+}
+
+func (suite *AuditorSuite) TestMarshalLogObject() {
+	enc := zapcore.NewMapObjectEncoder()
+	suite.NoError(Event{Time: suite.now, ClientID: "client", Method: "GET", Path: "/ping", Decision: DecisionDeny, Reason: "reason", TokenID: "jti", SourceIP: "10.0.0.1", RequestID: "request"}.MarshalLogObject(enc))
+	suite.Equal(map[string]interface{}{
+		"time":       suite.now,
+		"client_id":  "client",
+		"method":     "GET",
+		"path":       "/ping",
+		"decision":   "deny",
+		"reason":     "reason",
+		"token_id":   "jti",
+		"source_ip":  "10.0.0.1",
+		"request_id": "request",
+	}, enc.Fields)
+}
+
+func TestAuditorSuite(t *testing.T) {
+	suite.Run(t, new(AuditorSuite))
+}
+
+type testAddr string
+
+func (t testAddr) Network() string {
+	return string(t)
+}
+
+func (t testAddr) String() string {
+	return string(t)
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"cloud.google.com/go/pubsub"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/justdomepaul/toolbox/database/postgres"
+	pubsubTool "github.com/justdomepaul/toolbox/database/pubsub"
+	"go.uber.org/zap"
+	"regexp"
+)
+
+var (
+	// ErrInvalidTable table name is not [schema.]identifier
+	ErrInvalidTable = errors.New("invalid audit table name")
+
+	tableRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+)
+
+// NewZapSink method
+// logs every event as "audit" entry with event object
+func NewZapSink(logger *zap.Logger) Sink {
+	return zapSink{logger: logger}
+}
+
+type zapSink struct {
+	logger *zap.Logger
+}
+
+// Write method
+func (s zapSink) Write(_ context.Context, events []Event) error {
+	for _, event := range events {
+		s.logger.Info("audit", zap.Object("event", event))
+	}
+	return nil
+}
+
+// PostgresSchema method
+// DDL of audit table used by NewPostgresSink
+func PostgresSchema(table string) (string, error) {
+	if !tableRegexp.MatchString(table) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTable, table)
+	}
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	time TIMESTAMPTZ NOT NULL,
+	client_id TEXT NOT NULL DEFAULT '',
+	method TEXT NOT NULL DEFAULT '',
+	path TEXT NOT NULL DEFAULT '',
+	decision TEXT NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	token_id TEXT NOT NULL DEFAULT '',
+	source_ip TEXT NOT NULL DEFAULT '',
+	request_id TEXT NOT NULL DEFAULT ''
+)`, table), nil
+}
+
+// NewPostgresSink method
+// inserts events by batch into table created by PostgresSchema
+func NewPostgresSink(session postgres.ISession, table string) (Sink, error) {
+	if !tableRegexp.MatchString(table) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTable, table)
+	}
+	return postgresSink{
+		session: session,
+		query: fmt.Sprintf(`INSERT INTO %s (time, client_id, method, path, decision, reason, token_id, source_ip, request_id) `+
+			`VALUES (:time, :client_id, :method, :path, :decision, :reason, :token_id, :source_ip, :request_id)`, table),
+	}, nil
+}
+
+type postgresSink struct {
+	session postgres.ISession
+	query   string
+}
+
+// Write method
+func (s postgresSink) Write(ctx context.Context, events []Event) error {
+	_, err := s.session.NamedExecContext(ctx, s.query, events)
+	return err
+}
+
+// NewPubSubSink method
+// publishes every event as JSON message with decision attribute to topic
+func NewPubSubSink(session pubsubTool.ISession, topicID string) Sink {
+	return pubSubSink{topic: session.Topic(topicID)}
+}
+
+type pubSubSink struct {
+	topic *pubsub.Topic
+}
+
+// Write method
+func (s pubSubSink) Write(ctx context.Context, events []Event) error {
+	results := make([]*pubsub.PublishResult, 0, len(events))
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		results = append(results, s.topic.Publish(ctx, &pubsub.Message{
+			Data:       data,
+			Attributes: map[string]string{"decision": string(event.Decision)},
+		}))
+	}
+	errs := make([]error, 0)
+	for _, result := range results {
+		if _, err := result.Get(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
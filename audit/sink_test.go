@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/justdomepaul/toolbox/database/postgres"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"testing"
+	"time"
+)
+
+type mockPostgresSession struct {
+	postgres.ISession
+	mock.Mock
+}
+
+func (m *mockPostgresSession) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	args := m.Called(ctx, query, arg)
+	return nil, args.Error(1)
+}
+
+type SinkSuite struct {
+	suite.Suite
+	events []Event
+}
+
+func (suite *SinkSuite) SetupTest() {
+	suite.events = []Event{
+		{Time: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), ClientID: "client", Method: "/pb.Service/Get", Decision: DecisionAllow},
+		{Time: time.Date(2023, 1, 2, 3, 4, 6, 0, time.UTC), Method: "/pb.Service/Get", Decision: DecisionDeny, Reason: "token expired"},
+	}
+}
+
+func (suite *SinkSuite) TestZapSink() {
+	core, logs := observer.New(zapcore.InfoLevel)
+	suite.NoError(NewZapSink(zap.New(core)).Write(context.Background(), suite.events))
+	suite.Equal(2, logs.Len())
+	suite.Equal("audit", logs.All()[1].Message)
+	suite.Equal("token expired", logs.All()[1].ContextMap()["event"].(map[string]interface{})["reason"])
+}
+
+func (suite *SinkSuite) TestZapSinkMasking() {
+	core, logs := observer.New(zapcore.InfoLevel)
+	masker := zapTool.NewMasker(zapTool.DefaultMaskKeys, zapTool.MaskPatterns...)
+	event := Event{ClientID: "max@example.com", Method: "/pb.Service/Get", Decision: DecisionAllow, TokenID: "jti"}
+	suite.NoError(NewZapSink(zap.New(masker.Core(core))).Write(context.Background(), []Event{event}))
+	suite.Equal(1, logs.Len())
+	fields := logs.All()[0].ContextMap()["event"].(map[string]interface{})
+	suite.Equal("jti", fields["token_id"])
+	suite.Equal("max@example.com", fields["client_id"])
+}
+
+func (suite *SinkSuite) TestPostgresSchema() {
+	schema, err := PostgresSchema("audit.events")
+	suite.NoError(err)
+	suite.Contains(schema, "CREATE TABLE IF NOT EXISTS audit.events (")
+	suite.Contains(schema, "request_id TEXT")
+
+	_, err = PostgresSchema("events; DROP TABLE users")
+	suite.ErrorIs(err, ErrInvalidTable)
+}
+
+func (suite *SinkSuite) TestPostgresSink() {
+	session := &mockPostgresSession{}
+	session.On("NamedExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return query == "INSERT INTO audit_events (time, client_id, method, path, decision, reason, token_id, source_ip, request_id) "+
+			"VALUES (:time, :client_id, :method, :path, :decision, :reason, :token_id, :source_ip, :request_id)"
+	}), suite.events).Return(nil, errors.New("insert error")).Once()
+	sink, err := NewPostgresSink(session, "audit_events")
+	suite.NoError(err)
+	suite.EqualError(sink.Write(context.Background(), suite.events), "insert error")
+	session.AssertExpectations(suite.T())
+
+	_, err = NewPostgresSink(session, "1events")
+	suite.ErrorIs(err, ErrInvalidTable)
+}
+
+func (suite *SinkSuite) TestPubSubSink() {
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+	client, err := pubsub.NewClient(ctx, "project", option.WithEndpoint(srv.Addr), option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())))
+	suite.NoError(err)
+	defer client.Close()
+	_, err = client.CreateTopic(ctx, "audit")
+	suite.NoError(err)
+
+	suite.NoError(NewPubSubSink(client, "audit").Write(ctx, suite.events))
+	messages := srv.Messages()
+	suite.Len(messages, 2)
+	var event Event
+	suite.NoError(json.Unmarshal(messages[1].Data, &event))
+	suite.Equal(suite.events[1], event)
+	suite.Equal(map[string]string{"decision": "deny"}, messages[1].Attributes)
+
+	suite.Error(NewPubSubSink(client, "missing").Write(ctx, suite.events))
+}
+
+func TestSinkSuite(t *testing.T) {
+	suite.Run(t, new(SinkSuite))
+}
@@ -1,6 +1,9 @@
 package config
 
-import "time"
+import (
+	"go.uber.org/zap/zapcore"
+	"time"
+)
 
 // Cassandra type
 type Cassandra struct {
@@ -9,10 +12,34 @@ type Cassandra struct {
 	CassandraHosts             []string      `split_words:"true" default:"127.0.0.1"`
 	CassandraForcePassword     bool          `split_words:"true" default:"false"`
 	CassandraUsername          string        `split_words:"true" default:"cassandra"`
-	CassandraPassword          string        `split_words:"true" default:"cassandra"`
+	CassandraPassword          string        `split_words:"true" default:"cassandra" secret:"true"`
 	CassandraTimeout           time.Duration `split_words:"true" default:"600s"`
 	CassandraConnectionTimeout time.Duration `split_words:"true" default:"600s"`
 	ReconnectInterval          time.Duration `split_words:"true" default:"60s"`
 	CassandraNumConnection     int           `split_words:"true" default:"5"`
 	DisableInitialHostLookup   bool          `split_words:"true" default:"false"`
 }
+
+// Validate method
+func (c Cassandra) Validate() error {
+	return newValidation("Cassandra").
+		check(c.CassandraKeySpace != "", "CassandraKeySpace is required").
+		check(validPort(c.CassandraPort), "CassandraPort %q is not a valid port", c.CassandraPort).
+		check(len(c.CassandraHosts) > 0, "CassandraHosts is required").
+		check(c.CassandraNumConnection > 0, "CassandraNumConnection must be positive").
+		check(c.CassandraTimeout >= 0 && c.CassandraConnectionTimeout >= 0 && c.ReconnectInterval >= 0, "timeouts must not be negative").
+		check(!c.CassandraForcePassword || (c.CassandraUsername != "" && c.CassandraPassword != ""), "CassandraForcePassword requires CassandraUsername and CassandraPassword").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (c Cassandra) String() string {
+	return redact(c)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (c Cassandra) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, c)
+}
@@ -1,5 +1,7 @@
 package config
 
+import "go.uber.org/zap/zapcore"
+
 // Cloud type
 type Cloud struct {
 	ProjectID             string `split_words:"true" default:"test-project"`
@@ -7,3 +9,22 @@ type Cloud struct {
 	WithoutAuthentication bool   `split_words:"true" default:"true"`
 	GRPCInsecure          bool   `split_words:"true" default:"true"`
 }
+
+// Validate method
+func (c Cloud) Validate() error {
+	return newValidation("Cloud").
+		check(c.ProjectID != "", "ProjectID is required").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (c Cloud) String() string {
+	return redact(c)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (c Cloud) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, c)
+}
@@ -1,15 +1,40 @@
 package config
 
-import "time"
+import (
+	"go.uber.org/zap/zapcore"
+	"time"
+)
 
 // Cockroach type
 type Cockroach struct {
 	CockroachUsername  string        `split_words:"true" default:"root"`
-	CockroachPassword  string        `split_words:"true" default:""`
+	CockroachPassword  string        `split_words:"true" default:"" secret:"true"`
 	CockroachHost      string        `split_words:"true" default:"localhost"`
 	CockroachPort      string        `split_words:"true" default:"26257"`
 	CockroachDatabase  string        `split_words:"true" default:"defaultdb"`
 	CockroachSSLMode   string        `split_words:"true" default:"disable"`
-	CockroachURL       string        `split_words:"true" default:"postgres://root@localhost:26257/defaultdb?sslmode=disable"`
+	CockroachURL       string        `split_words:"true" default:"postgres://root@localhost:26257/defaultdb?sslmode=disable" secret:"true"`
 	CockroachTxTimeout time.Duration `split_words:"true" default:"5s"`
 }
+
+// Validate method
+func (c Cockroach) Validate() error {
+	return newValidation("Cockroach").
+		check(validURL(c.CockroachURL), "CockroachURL is not a valid URL").
+		check(c.CockroachURL != "" || c.CockroachHost != "", "CockroachHost is required when CockroachURL not set").
+		check(c.CockroachURL != "" || validPort(c.CockroachPort), "CockroachPort %q is not a valid port", c.CockroachPort).
+		check(c.CockroachTxTimeout >= 0, "CockroachTxTimeout must not be negative").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (c Cockroach) String() string {
+	return redact(c)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (c Cockroach) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, c)
+}
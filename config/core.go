@@ -1,7 +1,53 @@
 package config
 
+import (
+	"go.uber.org/zap/zapcore"
+	"time"
+)
+
 // Core type
 type Core struct {
-	LoggerMode string `split_words:"true" default:"customized"`
-	SystemName string `split_words:"true" default:"system"`
+	LoggerMode        string            `split_words:"true" default:"customized"`
+	LoggerLevel       string            `split_words:"true" default:""` // empty means debug in development mode, info otherwise
+	LoggerNamedLevels map[string]string `split_words:"true" default:""` // per named logger level, e.g. database:debug,grpc:warn
+	SystemName        string            `split_words:"true" default:"system"`
+
+	// customized mode outputs
+	LoggerEncoding           string        `split_words:"true" default:"json"`   // json, console or gcp (Google Cloud Logging)
+	LoggerSinks              []string      `split_words:"true" default:"stdout"` // stdout, stderr, file:///var/log/app.log, udp://host:514, per sink encoding by ?encoding=console
+	LoggerFileMaxSizeMB      int           `split_words:"true" default:"100"`    // rotate file sink when exceeded
+	LoggerFileMaxAge         time.Duration `split_words:"true" default:"0s"`     // remove rotated files older than, 0 keeps
+	LoggerFileMaxBackups     int           `split_words:"true" default:"0"`      // rotated files kept, 0 keeps all
+	LoggerFileCompress       bool          `split_words:"true" default:"false"`  // gzip rotated files
+	LoggerSamplingInitial    int           `split_words:"true" default:"0"`      // entries per tick logged before sampling, 0 disables sampling
+	LoggerSamplingThereafter int           `split_words:"true" default:"100"`    // every Nth entry logged after initial
+	LoggerSamplingTick       time.Duration `split_words:"true" default:"1s"`
+	LoggerMasking            bool          `split_words:"true" default:"false"`                               // opt-in, mask sensitive keys, JWTs and emails
+	LoggerMaskKeys           []string      `split_words:"true" default:"authorization,password,secret,token"` // matched case-insensitively as substring
+}
+
+// Validate method
+func (c Core) Validate() error {
+	return newValidation("Core").
+		check(c.LoggerMode == "development" || c.LoggerMode == "production" || c.LoggerMode == "customized", "LoggerMode %q must be development, production or customized", c.LoggerMode).
+		check(c.LoggerLevel == "" || validLevel(c.LoggerLevel), "LoggerLevel %q is not a valid level", c.LoggerLevel).
+		check(validNamedLevels(c.LoggerNamedLevels), "LoggerNamedLevels %v contains invalid level", c.LoggerNamedLevels).
+		check(c.SystemName != "", "SystemName is required").
+		check(validEncoding(c.LoggerEncoding), "LoggerEncoding %q must be json, console or gcp", c.LoggerEncoding).
+		check(validSinks(c.LoggerSinks), "LoggerSinks %v contains invalid sink", c.LoggerSinks).
+		check(c.LoggerFileMaxSizeMB >= 0 && c.LoggerFileMaxAge >= 0 && c.LoggerFileMaxBackups >= 0, "LoggerFileMaxSizeMB, LoggerFileMaxAge and LoggerFileMaxBackups must not be negative").
+		check(c.LoggerSamplingInitial <= 0 || c.LoggerSamplingTick > 0, "LoggerSamplingTick must be positive when sampling enabled").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (c Core) String() string {
+	return redact(c)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (c Core) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, c)
 }
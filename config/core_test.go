@@ -25,6 +25,7 @@ func (suite *CoreSuite) TestDefaultOption() {
 	suite.NoError(LoadFromEnv(core))
 	suite.Equal(suite.LoggerMode, core.LoggerMode)
 	suite.Equal(suite.SystemName, core.SystemName)
+	suite.False(core.LoggerMasking)
 }
 
 func TestCoreSuite(t *testing.T) {
@@ -1,8 +1,31 @@
 package config
 
+import "go.uber.org/zap/zapcore"
+
 // Firebase type
 type Firebase struct {
-	FirebaseConfigJSON       string `split_words:"true" default:""`
-	FirebaseConfigJSONBase64 string `split_words:"true" default:""`
+	FirebaseConfigJSON       string `split_words:"true" default:"" secret:"true"`
+	FirebaseConfigJSONBase64 string `split_words:"true" default:"" secret:"true"`
 	FirebaseProjectID        string `split_words:"true" default:""`
 }
+
+// Validate method
+func (f Firebase) Validate() error {
+	return newValidation("Firebase").
+		check(f.FirebaseConfigJSON == "" || f.FirebaseConfigJSONBase64 == "", "only one of FirebaseConfigJSON and FirebaseConfigJSONBase64 can be set").
+		check(validJSON(f.FirebaseConfigJSON), "FirebaseConfigJSON is not valid JSON").
+		check(validBase64(f.FirebaseConfigJSONBase64), "FirebaseConfigJSONBase64 is not valid base64").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (f Firebase) String() string {
+	return redact(f)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (f Firebase) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, f)
+}
@@ -1,7 +1,28 @@
 package config
 
+import "go.uber.org/zap/zapcore"
+
 // Firestore type
 type Firestore struct {
 	ProjectID string `split_words:"true" default:""`
 	EndPoint  string `split_words:"true" default:""`
 }
+
+// Validate method
+func (f Firestore) Validate() error {
+	return newValidation("Firestore").
+		check(f.EndPoint == "" || f.ProjectID != "", "ProjectID is required when EndPoint set").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (f Firestore) String() string {
+	return redact(f)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (f Firestore) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, f)
+}
@@ -1,21 +1,81 @@
 package config
 
 import (
+	"go.uber.org/zap/zapcore"
 	"time"
 )
 
 // GRPC type
 type GRPC struct {
 	Port                         string        `split_words:"true" default:"38080"`
-	NoTLS                        bool          `split_words:"true" default:"true"`  // use WithInsecure option
+	NoTLS                        bool          `split_words:"true" default:"true"`  // use WithInsecure option, overridden by SkipTLS, TLS, TLSNil and ALTS
 	SkipTLS                      bool          `split_words:"true" default:"false"` // use WithTransportCredentials option and include InsecureSkipVerify=true
 	TLS                          bool          `split_words:"true" default:"false"` // use WithTransportCredentials option and include PemCert
 	TLSNil                       bool          `split_words:"true" default:"false"` // use WithTransportCredentials option and include Use RootCA
-	TLSPemCert                   string        `split_words:"true" default:"false"` // TLS PemCert data
-	TLSPemCertBase64             string        `split_words:"true" default:"false"` // TLS PemCert base64encode data
+	TLSPemCert                   string        `split_words:"true" default:""`      // TLS PemCert data
+	TLSPemCertBase64             string        `split_words:"true" default:""`      // TLS PemCert base64encode data
 	ALTS                         bool          `split_words:"true" default:"false"` // client use grpc.WithTransportCredentials(alts.NewClientCreds(alts.DefaultClientOptions())); server use grpc.Creds(alts.NewServerCreds(alts.DefaultServerOptions()))
 	KeepAliveTime                time.Duration `split_words:"true" default:"1s"`    // second
 	KeepAliveTimeout             time.Duration `split_words:"true" default:"20s"`   //second
 	KeepAlivePermitWithoutStream bool          `split_words:"true" default:"true"`
 	AllowedList                  []string      `split_words:"true" default:"/auth.Auth/Ping,/auth.Auth/Authorization"`
+	ServerTLSCert                string        `split_words:"true" default:""`               // server TLS certificate PEM data, server TLS enabled when server certificate set
+	ServerTLSCertBase64          string        `split_words:"true" default:""`               // server TLS certificate PEM base64encode data
+	ServerTLSCertFile            string        `split_words:"true" default:""`               // server TLS certificate PEM file, reloaded when modified
+	ServerTLSKey                 string        `split_words:"true" default:"" secret:"true"` // server TLS private key PEM data
+	ServerTLSKeyBase64           string        `split_words:"true" default:"" secret:"true"` // server TLS private key PEM base64encode data
+	ServerTLSKeyFile             string        `split_words:"true" default:""`               // server TLS private key PEM file, reloaded when modified
+	ServerTLSClientCA            string        `split_words:"true" default:""`               // client CA PEM data, client certificate is required and verified (mTLS) when client CA set
+	ServerTLSClientCABase64      string        `split_words:"true" default:""`               // client CA PEM base64encode data
+	ServerTLSClientCAFile        string        `split_words:"true" default:""`               // client CA PEM file, reloaded when modified
+	ServerTLSReloadInterval      time.Duration `split_words:"true" default:"10s"`            // min interval of checking file modification on handshake
+}
+
+// Insecure method
+// NoTLS enabled and not overridden by SkipTLS, TLS, TLSNil or ALTS
+func (g GRPC) Insecure() bool {
+	return g.NoTLS && countTrue(g.SkipTLS, g.TLS, g.TLSNil, g.ALTS) == 0
+}
+
+// ServerTLS method
+// server TLS certificate configured
+func (g GRPC) ServerTLS() bool {
+	return countTrue(g.ServerTLSCert != "", g.ServerTLSCertBase64 != "", g.ServerTLSCertFile != "") > 0
+}
+
+// ServerMTLS method
+// client CA configured
+func (g GRPC) ServerMTLS() bool {
+	return countTrue(g.ServerTLSClientCA != "", g.ServerTLSClientCABase64 != "", g.ServerTLSClientCAFile != "") > 0
+}
+
+// Validate method
+func (g GRPC) Validate() error {
+	return newValidation("GRPC").
+		check(validPort(g.Port), "Port %q is not a valid port", g.Port).
+		check(countTrue(g.SkipTLS, g.TLS, g.TLSNil, g.ALTS) <= 1, "only one of SkipTLS, TLS, TLSNil and ALTS can be enabled").
+		check(!g.TLS || g.TLSPemCert != "" || g.TLSPemCertBase64 != "", "TLS requires TLSPemCert or TLSPemCertBase64").
+		check(validBase64(g.TLSPemCertBase64), "TLSPemCertBase64 is not valid base64").
+		check(g.KeepAliveTime >= 0 && g.KeepAliveTimeout >= 0, "KeepAliveTime and KeepAliveTimeout must not be negative").
+		check(countTrue(g.ServerTLSCert != "", g.ServerTLSCertBase64 != "", g.ServerTLSCertFile != "") <= 1, "only one of ServerTLSCert, ServerTLSCertBase64 and ServerTLSCertFile can be set").
+		check(countTrue(g.ServerTLSKey != "", g.ServerTLSKeyBase64 != "", g.ServerTLSKeyFile != "") <= 1, "only one of ServerTLSKey, ServerTLSKeyBase64 and ServerTLSKeyFile can be set").
+		check(countTrue(g.ServerTLSClientCA != "", g.ServerTLSClientCABase64 != "", g.ServerTLSClientCAFile != "") <= 1, "only one of ServerTLSClientCA, ServerTLSClientCABase64 and ServerTLSClientCAFile can be set").
+		check(g.ServerTLS() == (countTrue(g.ServerTLSKey != "", g.ServerTLSKeyBase64 != "", g.ServerTLSKeyFile != "") > 0), "server TLS requires both certificate and key").
+		check(g.ServerTLS() || !g.ServerMTLS(), "ServerTLSClientCA requires server TLS certificate").
+		check(!g.ServerTLS() || !g.ALTS, "server TLS and ALTS cannot be enabled together").
+		check(validBase64(g.ServerTLSCertBase64) && validBase64(g.ServerTLSKeyBase64) && validBase64(g.ServerTLSClientCABase64), "server TLS base64 data is not valid base64").
+		check(g.ServerTLSReloadInterval >= 0, "ServerTLSReloadInterval must not be negative").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (g GRPC) String() string {
+	return redact(g)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (g GRPC) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, g)
 }
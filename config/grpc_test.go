@@ -56,6 +56,14 @@ func (suite *GRPCSuite) TestDefaultOption() {
 	suite.Equal(suite.AllowedList, grpc.AllowedList)
 }
 
+func (suite *GRPCSuite) TestServerTLS() {
+	suite.False(GRPC{}.ServerTLS())
+	suite.False(GRPC{}.ServerMTLS())
+	suite.True(GRPC{ServerTLSCertFile: "cert.pem"}.ServerTLS())
+	suite.True(GRPC{ServerTLSClientCABase64: "Y2E="}.ServerMTLS())
+	suite.NotContains(GRPC{ServerTLSKey: "private key"}.String(), "private key")
+}
+
 func TestGRPCSuite(t *testing.T) {
 	suite.Run(t, new(GRPCSuite))
 }
@@ -1,12 +1,33 @@
 package config
 
+import "go.uber.org/zap/zapcore"
+
 // JWT type
 type JWT struct {
 	MetadataClientIDKey string `split_words:"true" default:"authorization-clientID"`
 	EcdsaPrivateKeyPath string `split_words:"true" default:"./es256_private.pem"`
-	EcdsaPrivateKey     string `split_words:"true" default:""`
+	EcdsaPrivateKey     string `split_words:"true" default:"" secret:"true"`
 	RsaPrivateKeyPath   string `split_words:"true" default:"./rs256-private.pem"`
-	RsaPrivateKey       string `split_words:"true" default:""`
+	RsaPrivateKey       string `split_words:"true" default:"" secret:"true"`
 	HmacSecretKeyPath   string `split_words:"true" default:"./hs-secret.pem"`
-	HmacSecretKey       string `split_words:"true" default:""`
+	HmacSecretKey       string `split_words:"true" default:"" secret:"true"`
+}
+
+// Validate method
+func (j JWT) Validate() error {
+	return newValidation("JWT").
+		check(j.MetadataClientIDKey != "", "MetadataClientIDKey is required").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (j JWT) String() string {
+	return redact(j)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (j JWT) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, j)
 }
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kelseyhightower/envconfig"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var (
+	lookupEnv = os.LookupEnv
+)
+
+// decodeKey environment key of field decoded by envconfig, never found since keys cannot contain "="
+const decodeKey = "TOOLBOX=DECODE"
+
+// LoaderOption interface
+type LoaderOption interface {
+	Apply(*Loader)
+}
+
+// WithPrefix method
+// environment and .env keys are looked up as PREFIX_KEY first then KEY
+func WithPrefix(prefix string) LoaderOption {
+	return withPrefix{prefix: prefix}
+}
+
+type withPrefix struct {
+	prefix string
+}
+
+// Apply method
+func (w withPrefix) Apply(l *Loader) {
+	l.prefix = strings.ToUpper(w.prefix)
+}
+
+// WithFile method
+// YAML (.yaml, .yml), JSON (.json) or TOML (.toml) file, later file overrides former
+func WithFile(paths ...string) LoaderOption {
+	return withFile{paths: paths}
+}
+
+type withFile struct {
+	paths []string
+}
+
+// Apply method
+func (w withFile) Apply(l *Loader) {
+	l.files = append(l.files, w.paths...)
+}
+
+// WithDotEnv method
+// .env file, later file overrides former
+func WithDotEnv(paths ...string) LoaderOption {
+	return withDotEnv{paths: paths}
+}
+
+type withDotEnv struct {
+	paths []string
+}
+
+// Apply method
+func (w withDotEnv) Apply(l *Loader) {
+	l.dotEnvs = append(l.dotEnvs, w.paths...)
+}
+
+// WithArgs method
+// command-line flags (e.g. os.Args[1:]), flag name is lower kebab-case key, e.g. --redis-host,
+// unknown flags and positional arguments are ignored
+func WithArgs(args []string) LoaderOption {
+	return withArgs{args: args}
+}
+
+type withArgs struct {
+	args []string
+}
+
+// Apply method
+func (w withArgs) Apply(l *Loader) {
+	l.args = w.args
+}
+
+// Loader type
+// loads config structs by envconfig.Process (split_words, envconfig, default, required, ignored tags)
+// layer by layer: default -> file -> .env -> environment -> flags,
+// process environment is only read, never modified
+type Loader struct {
+	prefix   string
+	files    []string
+	dotEnvs  []string
+	args     []string
+	sections []string
+}
+
+// NewLoader method
+func NewLoader(options ...LoaderOption) *Loader {
+	l := &Loader{}
+	for _, option := range options {
+		option.Apply(l)
+	}
+	return l
+}
+
+type fieldInfo struct {
+	Name  string
+	Key   string
+	Tags  reflect.StructTag
+	Field reflect.Value
+}
+
+// Load method
+func (l *Loader) Load(pointers ...interface{}) error {
+	infos := make([]fieldInfo, 0)
+	for _, pointer := range pointers {
+		fields, err := gatherInfo(pointer)
+		if err != nil {
+			return err
+		}
+		infos = append(infos, fields...)
+	}
+
+	fileValues, err := readFiles(l.files)
+	if err != nil {
+		return err
+	}
+	dotEnvValues, err := readDotEnvs(l.dotEnvs)
+	if err != nil {
+		return err
+	}
+	flagValues, err := parseArgs(l.args, infos)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		value, ok := flagValues[flagName(info.Key)]
+		if !ok {
+			value, ok = l.lookup(info.Key, lookupEnv)
+		}
+		if !ok {
+			value, ok = l.lookup(info.Key, func(key string) (string, bool) {
+				value, ok := dotEnvValues[key]
+				return value, ok
+			})
+		}
+		if !ok {
+			value, ok = fileValues[normalizeKey(info.Key)]
+		}
+		if !ok {
+			value = info.Tags.Get("default")
+		}
+		if !ok && value == "" {
+			if isTrue(info.Tags.Get("required")) {
+				return fmt.Errorf("required key %s missing value", info.Key)
+			}
+			continue
+		}
+		if err := decode(info, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Loader) lookup(key string, lookup func(string) (string, bool)) (string, bool) {
+	if l.prefix != "" {
+		if value, ok := lookup(l.prefix + "_" + key); ok {
+			return value, true
+		}
+	}
+	return lookup(key)
+}
+
+func (l *Loader) envKey(key string) string {
+	if l.prefix == "" {
+		return key
+	}
+	return l.prefix + "_" + key
+}
+
+// gatherInfo collects fields of spec by envconfig, keys are same as envconfig.Process
+func gatherInfo(spec interface{}) ([]fieldInfo, error) {
+	infos := make([]fieldInfo, 0)
+	tmpl, err := template.New("gather").Funcs(template.FuncMap{
+		"gather": func(name, key string, tags reflect.StructTag, field reflect.Value) string {
+			infos = append(infos, fieldInfo{Name: name, Key: key, Tags: tags, Field: field})
+			return ""
+		},
+	}).Parse("{{range .}}{{gather .Name .Key .Tags .Field}}{{end}}")
+	if err != nil {
+		return nil, err
+	}
+	if err := envconfig.Usaget("", spec, io.Discard, tmpl); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// decode sets field of info to value parsed as envconfig.Process, empty value sets zero value
+func decode(info fieldInfo, value string) error {
+	if value == "" {
+		info.Field.Set(reflect.Zero(info.Field.Type()))
+		return nil
+	}
+	spec := reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Value",
+		Type: info.Field.Type(),
+		Tag:  reflect.StructTag(`envconfig:"` + decodeKey + `" default:` + strconv.Quote(value)),
+	}}))
+	if err := envconfig.Process("", spec.Interface()); err != nil {
+		var parseErr *envconfig.ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.KeyName, parseErr.FieldName = info.Key, info.Name
+		}
+		return err
+	}
+	info.Field.Set(spec.Elem().Field(0))
+	return nil
+}
+
+func isTrue(s string) bool {
+	b, _ := strconv.ParseBool(s)
+	return b
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"github.com/kelseyhightower/envconfig"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type loaderTestConfig struct {
+	Host     string            `split_words:"true" default:"localhost"`
+	Port     int               `split_words:"true" default:"8080"`
+	Debug    bool              `split_words:"true" default:"false"`
+	Timeout  time.Duration     `split_words:"true" default:"5s"`
+	Origins  []string          `split_words:"true" default:"a,b"`
+	Labels   map[string]string `split_words:"true"`
+	Password string            `split_words:"true" secret:"true"`
+	Custom   string            `envconfig:"CUSTOM_NAME"`
+	Ignored  string            `ignored:"true" default:"ignored"`
+	Nested   loaderTestNested
+}
+
+type loaderTestNested struct {
+	Ratio float64 `default:"0.5"`
+}
+
+type loaderTestRequired struct {
+	Token string `required:"true"`
+}
+
+// loaderTestEnv records process environment of its key while decoded
+type loaderTestEnv struct {
+	found bool
+}
+
+// Decode method
+func (e *loaderTestEnv) Decode(string) error {
+	_, e.found = os.LookupEnv("LOADER_TEST_ENV")
+	return nil
+}
+
+type loaderTestEnvConfig struct {
+	LoaderTestEnv loaderTestEnv `envconfig:"LOADER_TEST_ENV"`
+}
+
+type LoaderSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *LoaderSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+	stubs := gostub.Stub(&lookupEnv, func(key string) (string, bool) { return "", false })
+	suite.T().Cleanup(stubs.Reset)
+}
+
+func (suite *LoaderSuite) write(name, content string) string {
+	path := filepath.Join(suite.dir, name)
+	suite.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func (suite *LoaderSuite) TestLoadDefault() {
+	c := loaderTestConfig{}
+	suite.NoError(NewLoader().Load(&c))
+	suite.Equal("localhost", c.Host)
+	suite.Equal(8080, c.Port)
+	suite.Equal(5*time.Second, c.Timeout)
+	suite.Equal([]string{"a", "b"}, c.Origins)
+	suite.Equal("", c.Ignored)
+	suite.Equal(0.5, c.Nested.Ratio)
+}
+
+func (suite *LoaderSuite) TestLoadEnv() {
+	defer gostub.Stub(&lookupEnv, func(key string) (string, bool) {
+		value, ok := map[string]string{
+			"HOST":            "env.host",
+			"SVC_PORT":        "9090",
+			"CUSTOM_NAME":     "custom",
+			"LABELS":          "a:1,b:2",
+			"NESTED_RATIO":    "0.75",
+			"SVC_DEBUG":       "true",
+			"SVC_CUSTOM_NAME": "prefixed custom",
+		}[key]
+		return value, ok
+	}).Reset()
+	c := loaderTestConfig{}
+	suite.NoError(NewLoader(WithPrefix("svc")).Load(&c))
+	suite.Equal("env.host", c.Host)
+	suite.Equal(9090, c.Port)
+	suite.True(c.Debug)
+	suite.Equal("prefixed custom", c.Custom)
+	suite.Equal(map[string]string{"a": "1", "b": "2"}, c.Labels)
+	suite.Equal(0.75, c.Nested.Ratio)
+}
+
+func (suite *LoaderSuite) TestLoadLayers() {
+	yamlFile := suite.write("config.yaml", "host: yaml.host\nport: 7070\norigins: [x, y]\nnested:\n  ratio: 0.1\ntimeout: 1s\n")
+	jsonFile := suite.write("config.json", `{"PORT": 6060}`)
+	dotEnv := suite.write(".env", "# comment\nexport HOST=dotenv.host\nPASSWORD=\"p@ss\\nword\"\n")
+	defer gostub.Stub(&lookupEnv, func(key string) (string, bool) {
+		if key == "PASSWORD" {
+			return "env password", true
+		}
+		return "", false
+	}).Reset()
+
+	c := loaderTestConfig{}
+	suite.NoError(NewLoader(
+		WithFile(yamlFile, jsonFile),
+		WithDotEnv(dotEnv),
+		WithArgs([]string{"--debug", "--timeout=3s"}),
+	).Load(&c))
+	suite.Equal("dotenv.host", c.Host)
+	suite.Equal(6060, c.Port)
+	suite.Equal([]string{"x", "y"}, c.Origins)
+	suite.Equal(0.1, c.Nested.Ratio)
+	suite.Equal("env password", c.Password)
+	suite.True(c.Debug)
+	suite.Equal(3*time.Second, c.Timeout)
+}
+
+func (suite *LoaderSuite) TestLoadRequired() {
+	suite.EqualError(NewLoader().Load(&loaderTestRequired{}), "required key TOKEN missing value")
+	c := loaderTestRequired{}
+	suite.NoError(NewLoader(WithArgs([]string{"-token", "t"})).Load(&c))
+	suite.Equal("t", c.Token)
+}
+
+func (suite *LoaderSuite) TestLoadParseError() {
+	err := NewLoader(WithArgs([]string{"--port=abc"})).Load(&loaderTestConfig{})
+	suite.IsType(&envconfig.ParseError{}, err)
+}
+
+func (suite *LoaderSuite) TestLoadInvalidSpecification() {
+	suite.ErrorIs(NewLoader().Load(loaderTestConfig{}), envconfig.ErrInvalidSpecification)
+	host := ""
+	suite.ErrorIs(NewLoader().Load(&host), envconfig.ErrInvalidSpecification)
+}
+
+func (suite *LoaderSuite) TestLoadSourceError() {
+	suite.Error(NewLoader(WithFile(filepath.Join(suite.dir, "missing.yaml"))).Load(&loaderTestConfig{}))
+	suite.Error(NewLoader(WithDotEnv(filepath.Join(suite.dir, "missing.env"))).Load(&loaderTestConfig{}))
+	suite.Error(NewLoader(WithArgs([]string{"--host"})).Load(&loaderTestConfig{}))
+}
+
+func (suite *LoaderSuite) TestLoadIgnoreUnknownArgs() {
+	c := loaderTestConfig{}
+	suite.NoError(NewLoader(WithArgs([]string{"-test.v", "--unknown", "value", "--port=9000", "run"})).Load(&c))
+	suite.Equal(9000, c.Port)
+}
+
+func (suite *LoaderSuite) TestLoadSameBehaviorAsLoadFromEnv() {
+	defer gostub.Stub(&lookupEnv, os.LookupEnv).Reset()
+	suite.T().Setenv("REDIS_HOST", "redis.host")
+	suite.T().Setenv("ALLOW_ORIGINS", "http://a,http://b")
+	expected, actual := Set{}, Set{}
+	suite.NoError(LoadFromEnv(expected.pointers()...))
+	suite.NoError(NewLoader().Load(actual.pointers()...))
+	suite.Equal(expected, actual)
+}
+
+func (suite *LoaderSuite) TestLoadNeverModifyEnvironment() {
+	dotEnv := suite.write(".env", "LOADER_TEST_ENV=dotenv\nHOST=dotenv.host\n")
+	c, e := loaderTestConfig{}, loaderTestEnvConfig{}
+	suite.NoError(NewLoader(WithDotEnv(dotEnv)).Load(&c, &e))
+	suite.Equal("dotenv.host", c.Host)
+	suite.False(e.LoaderTestEnv.found)
+	_, ok := os.LookupEnv("LOADER_TEST_ENV")
+	suite.False(ok)
+}
+
+func TestLoaderSuite(t *testing.T) {
+	suite.Run(t, new(LoaderSuite))
+}
@@ -1,12 +1,34 @@
 package config
 
+import "go.uber.org/zap/zapcore"
+
 // Mongo type
 type Mongo struct {
 	MongoProtocol          string `split_words:"true" default:"mongodb+srv"`
 	MongoUsername          string `split_words:"true" default:""`
-	MongoPassword          string `split_words:"true" default:""`
+	MongoPassword          string `split_words:"true" default:"" secret:"true"`
 	MongoHost              string `split_words:"true" default:""`
 	MongoDatabase          string `split_words:"true" default:""`
 	MongoAuthSource        bool   `split_words:"true" default:""`
 	MongoIgnoreQueryString bool   `split_words:"true" default:""`
 }
+
+// Validate method
+func (m Mongo) Validate() error {
+	return newValidation("Mongo").
+		check(m.MongoProtocol == "mongodb" || m.MongoProtocol == "mongodb+srv", "MongoProtocol %q must be mongodb or mongodb+srv", m.MongoProtocol).
+		check(m.MongoPassword == "" || m.MongoUsername != "", "MongoUsername is required when MongoPassword set").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (m Mongo) String() string {
+	return redact(m)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (m Mongo) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, m)
+}
@@ -1,15 +1,40 @@
 package config
 
-import "time"
+import (
+	"go.uber.org/zap/zapcore"
+	"time"
+)
 
 // Postgresql type
 type Postgresql struct {
 	PostgresqlUsername  string        `split_words:"true" default:"root"`
-	PostgresqlPassword  string        `split_words:"true" default:""`
+	PostgresqlPassword  string        `split_words:"true" default:"" secret:"true"`
 	PostgresqlHost      string        `split_words:"true" default:"localhost"`
 	PostgresqlPort      string        `split_words:"true" default:"26257"`
 	PostgresqlDatabase  string        `split_words:"true" default:"defaultdb"`
 	PostgresqlSSLMode   string        `split_words:"true" default:"disable"`
-	PostgresqlURL       string        `split_words:"true" default:"postgres://root@localhost:26257/defaultdb?sslmode=disable"`
+	PostgresqlURL       string        `split_words:"true" default:"postgres://root@localhost:26257/defaultdb?sslmode=disable" secret:"true"`
 	PostgresqlTxTimeout time.Duration `split_words:"true" default:"5s"`
 }
+
+// Validate method
+func (p Postgresql) Validate() error {
+	return newValidation("Postgresql").
+		check(validURL(p.PostgresqlURL), "PostgresqlURL is not a valid URL").
+		check(p.PostgresqlURL != "" || p.PostgresqlHost != "", "PostgresqlHost is required when PostgresqlURL not set").
+		check(p.PostgresqlURL != "" || validPort(p.PostgresqlPort), "PostgresqlPort %q is not a valid port", p.PostgresqlPort).
+		check(p.PostgresqlTxTimeout >= 0, "PostgresqlTxTimeout must not be negative").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (p Postgresql) String() string {
+	return redact(p)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (p Postgresql) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, p)
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SecretMask replaces `secret:"true"` field value when printed
+const SecretMask = "******"
+
+// Print method
+// writes effective configuration of pointers as KEY=value lines, `secret:"true"` fields are masked
+func (l *Loader) Print(w io.Writer, pointers ...interface{}) error {
+	for _, pointer := range pointers {
+		infos, err := gatherInfo(pointer)
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			value := formatValue(info.Field)
+			if isTrue(info.Tags.Get("secret")) && !info.Field.IsZero() {
+				value = SecretMask
+			}
+			if _, err := fmt.Fprintf(w, "%s=%s\n", l.envKey(info.Key), value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatValue(field reflect.Value) string {
+	if !field.IsValid() {
+		return ""
+	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+		field = field.Elem()
+	}
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(field.Int()).String()
+	}
+	switch field.Kind() {
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.Uint8 {
+			return string(field.Bytes())
+		}
+		items := make([]string, 0, field.Len())
+		for i := 0; i < field.Len(); i++ {
+			items = append(items, formatValue(field.Index(i)))
+		}
+		return strings.Join(items, ",")
+	case reflect.Map:
+		items := make([]string, 0, field.Len())
+		iter := field.MapRange()
+		for iter.Next() {
+			items = append(items, formatValue(iter.Key())+":"+formatValue(iter.Value()))
+		}
+		sort.Strings(items)
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(field.Interface())
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type PrintSuite struct {
+	suite.Suite
+}
+
+func (suite *PrintSuite) TestPrint() {
+	c := loaderTestConfig{
+		Host:     "localhost",
+		Port:     8080,
+		Timeout:  time.Second,
+		Origins:  []string{"a", "b"},
+		Labels:   map[string]string{"b": "2", "a": "1"},
+		Password: "password",
+	}
+	buf := &bytes.Buffer{}
+	suite.NoError(NewLoader(WithPrefix("svc")).Print(buf, &c))
+	suite.Equal(`SVC_HOST=localhost
+SVC_PORT=8080
+SVC_DEBUG=false
+SVC_TIMEOUT=1s
+SVC_ORIGINS=a,b
+SVC_LABELS=a:1,b:2
+SVC_PASSWORD=******
+SVC_CUSTOM_NAME=
+SVC_NESTED_RATIO=0
+`, buf.String())
+}
+
+func (suite *PrintSuite) TestPrintEmptySecret() {
+	buf := &bytes.Buffer{}
+	suite.NoError(NewLoader().Print(buf, &Redis{}))
+	suite.Contains(buf.String(), "REDIS_PASSWORD=\n")
+}
+
+func (suite *PrintSuite) TestPrintInvalidSpecification() {
+	suite.Error(NewLoader().Print(&bytes.Buffer{}, Redis{}))
+}
+
+func TestPrintSuite(t *testing.T) {
+	suite.Run(t, new(PrintSuite))
+}
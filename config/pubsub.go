@@ -1,5 +1,7 @@
 package config
 
+import "go.uber.org/zap/zapcore"
+
 // PubSub type
 type PubSub struct {
 	ProjectID             string `split_words:"true" default:"test-project"`
@@ -7,3 +9,22 @@ type PubSub struct {
 	WithoutAuthentication bool   `split_words:"true" default:"true"`
 	GRPCInsecure          bool   `split_words:"true" default:"true"`
 }
+
+// Validate method
+func (p PubSub) Validate() error {
+	return newValidation("PubSub").
+		check(p.ProjectID != "", "ProjectID is required").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (p PubSub) String() string {
+	return redact(p)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (p PubSub) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, p)
+}
@@ -1,11 +1,34 @@
 package config
 
+import "go.uber.org/zap/zapcore"
+
 // Redis type
 type Redis struct {
 	RedisUsername   string `split_words:"true" default:""`
-	RedisPassword   string `split_words:"true" default:""`
+	RedisPassword   string `split_words:"true" default:"" secret:"true"`
 	RedisClientName string `split_words:"true" default:""`
 	RedisHost       string `split_words:"true" default:"localhost"`
 	RedisPort       string `split_words:"true" default:"6379"`
 	RedisPoolSize   int    `split_words:"true" default:"10"`
 }
+
+// Validate method
+func (r Redis) Validate() error {
+	return newValidation("Redis").
+		check(r.RedisHost != "", "RedisHost is required").
+		check(validPort(r.RedisPort), "RedisPort %q is not a valid port", r.RedisPort).
+		check(r.RedisPoolSize > 0, "RedisPoolSize must be positive").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (r Redis) String() string {
+	return redact(r)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (r Redis) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, r)
+}
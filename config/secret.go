@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"go.uber.org/zap/zapcore"
+	"reflect"
+	"strings"
+	"time"
+)
+
+func isSecret(field reflect.StructField, value reflect.Value) bool {
+	return isTrue(field.Tag.Get("secret")) && !value.IsZero()
+}
+
+// redact formats section like %+v, `secret:"true"` fields are masked
+func redact(section interface{}) string {
+	v := reflect.ValueOf(section)
+	t := v.Type()
+	items := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		value := fmt.Sprintf("%v", v.Field(i).Interface())
+		if isSecret(t.Field(i), v.Field(i)) {
+			value = SecretMask
+		}
+		items = append(items, t.Field(i).Name+":"+value)
+	}
+	return "{" + strings.Join(items, " ") + "}"
+}
+
+// marshalLogObject encodes section fields for zap, `secret:"true"` fields are masked
+func marshalLogObject(enc zapcore.ObjectEncoder, section interface{}) error {
+	v := reflect.ValueOf(section)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if isSecret(field, value) {
+			enc.AddString(field.Name, SecretMask)
+			continue
+		}
+		switch value.Kind() {
+		case reflect.String:
+			enc.AddString(field.Name, value.String())
+		case reflect.Bool:
+			enc.AddBool(field.Name, value.Bool())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if value.Type() == reflect.TypeOf(time.Duration(0)) {
+				enc.AddDuration(field.Name, time.Duration(value.Int()))
+				continue
+			}
+			enc.AddInt64(field.Name, value.Int())
+		default:
+			if err := enc.AddReflected(field.Name, value.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"testing"
+	"time"
+)
+
+type SecretSuite struct {
+	suite.Suite
+}
+
+func (suite *SecretSuite) TestString() {
+	redis := Redis{RedisHost: "localhost", RedisPassword: "password", RedisPoolSize: 10}
+	suite.Equal("{RedisUsername: RedisPassword:****** RedisClientName: RedisHost:localhost RedisPort: RedisPoolSize:10}", redis.String())
+	suite.NotContains(fmt.Sprint(redis), "password")
+	suite.NotContains(fmt.Sprintf("%+v", Set{Redis: redis}), "password")
+	suite.Contains(Redis{}.String(), "RedisPassword: ")
+}
+
+func (suite *SecretSuite) TestMarshalLogObject() {
+	enc := zapcore.NewMapObjectEncoder()
+	suite.NoError(Postgres{PostgresHost: "localhost", PostgresPassword: "password", PostgresTxTimeout: time.Second}.MarshalLogObject(enc))
+	suite.Equal("localhost", enc.Fields["PostgresHost"])
+	suite.Equal(SecretMask, enc.Fields["PostgresPassword"])
+	suite.Equal("", enc.Fields["PostgresURL"])
+	suite.Equal(time.Second, enc.Fields["PostgresTxTimeout"])
+
+	enc = zapcore.NewMapObjectEncoder()
+	suite.NoError(Server{AllowOrigins: []string{"a"}, JWTGuard: true, MaxMultipartMemoryMB: 8}.MarshalLogObject(enc))
+	suite.Equal([]string{"a"}, enc.Fields["AllowOrigins"])
+	suite.Equal(true, enc.Fields["JWTGuard"])
+	suite.Equal(int64(8), enc.Fields["MaxMultipartMemoryMB"])
+}
+
+func (suite *SecretSuite) TestZapField() {
+	core, logs := observer.New(zap.InfoLevel)
+	zap.New(core).Info("config",
+		zap.Object("jwt", JWT{HmacSecretKey: "secret"}),
+		zap.Object("set", Set{Redis: Redis{RedisPassword: "password"}}),
+	)
+	fields := logs.All()[0].ContextMap()
+	suite.Equal(SecretMask, fields["jwt"].(map[string]interface{})["HmacSecretKey"])
+	suite.Equal(SecretMask, fields["set"].(map[string]interface{})["Redis"].(map[string]interface{})["RedisPassword"])
+}
+
+func TestSecretSuite(t *testing.T) {
+	suite.Run(t, new(SecretSuite))
+}
@@ -0,0 +1,83 @@
+package config
+
+type section struct {
+	name    string
+	pointer func(*Set) interface{}
+}
+
+var sections = []section{
+	{name: "Cassandra", pointer: func(s *Set) interface{} { return &s.Cassandra }},
+	{name: "Cloud", pointer: func(s *Set) interface{} { return &s.Cloud }},
+	{name: "Cockroach", pointer: func(s *Set) interface{} { return &s.Cockroach }},
+	{name: "Core", pointer: func(s *Set) interface{} { return &s.Core }},
+	{name: "Firebase", pointer: func(s *Set) interface{} { return &s.Firebase }},
+	{name: "Firestore", pointer: func(s *Set) interface{} { return &s.Firestore }},
+	{name: "GRPC", pointer: func(s *Set) interface{} { return &s.GRPC }},
+	{name: "JWT", pointer: func(s *Set) interface{} { return &s.JWT }},
+	{name: "Mongo", pointer: func(s *Set) interface{} { return &s.Mongo }},
+	{name: "PubSub", pointer: func(s *Set) interface{} { return &s.PubSub }},
+	{name: "Postgres", pointer: func(s *Set) interface{} { return &s.Postgres }},
+	{name: "Postgresql", pointer: func(s *Set) interface{} { return &s.Postgresql }},
+	{name: "Redis", pointer: func(s *Set) interface{} { return &s.Redis }},
+	{name: "Server", pointer: func(s *Set) interface{} { return &s.Server }},
+	{name: "Spanner", pointer: func(s *Set) interface{} { return &s.Spanner }},
+}
+
+type withSection struct {
+	name string
+}
+
+// Apply method
+func (w withSection) Apply(l *Loader) {
+	for _, name := range l.sections {
+		if name == w.name {
+			return
+		}
+	}
+	l.sections = append(l.sections, w.name)
+}
+
+// WithCassandra method
+func WithCassandra() LoaderOption { return withSection{name: "Cassandra"} }
+
+// WithCloud method
+func WithCloud() LoaderOption { return withSection{name: "Cloud"} }
+
+// WithCockroach method
+func WithCockroach() LoaderOption { return withSection{name: "Cockroach"} }
+
+// WithCore method
+func WithCore() LoaderOption { return withSection{name: "Core"} }
+
+// WithFirebase method
+func WithFirebase() LoaderOption { return withSection{name: "Firebase"} }
+
+// WithFirestore method
+func WithFirestore() LoaderOption { return withSection{name: "Firestore"} }
+
+// WithGRPC method
+func WithGRPC() LoaderOption { return withSection{name: "GRPC"} }
+
+// WithJWT method
+func WithJWT() LoaderOption { return withSection{name: "JWT"} }
+
+// WithMongo method
+func WithMongo() LoaderOption { return withSection{name: "Mongo"} }
+
+// WithPubSub method
+func WithPubSub() LoaderOption { return withSection{name: "PubSub"} }
+
+// WithPostgres method
+func WithPostgres() LoaderOption { return withSection{name: "Postgres"} }
+
+// WithPostgresql method
+func WithPostgresql() LoaderOption { return withSection{name: "Postgresql"} }
+
+// WithRedis method
+func WithRedis() LoaderOption { return withSection{name: "Redis"} }
+
+// WithServer method
+func WithServer() LoaderOption { return withSection{name: "Server"} }
+
+// WithSpanner method
+func WithSpanner() LoaderOption { return withSection{name: "Spanner"} }
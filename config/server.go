@@ -1,20 +1,60 @@
 package config
 
 import (
+	"go.uber.org/zap/zapcore"
 	"time"
 )
 
 // Server type
 type Server struct {
-	ReleaseMode          bool          `split_words:"true" default:"true"`
-	Port                 string        `split_words:"true" default:"38080"`
-	MetricsPort          string        `split_words:"true" default:"38090"`
-	ServerTimeout        time.Duration `split_words:"true" default:"5s"`
-	PrefixMessage        string        `split_words:"true" default:"error gin server"`
-	CustomizedRender     bool          `split_words:"true" default:"false"`
-	AllowAllOrigins      bool          `split_words:"true" default:"false"`
-	AllowOrigins         []string      `split_words:"true" default:"http://localhost,https://localhost"`
-	AllowedPaths         []string      `split_words:"true" default:"/favicon.ico,/ping,/metrics,/api/auth/v1/authorization,/narrow_cast_schedule"`
-	JWTGuard             bool          `split_words:"true" default:"true"`
-	MaxMultipartMemoryMB int64         `split_words:"true" default:"8"`
+	ReleaseMode           bool          `split_words:"true" default:"true"`
+	Port                  string        `split_words:"true" default:"38080"`
+	MetricsPort           string        `split_words:"true" default:"38090"`
+	ServerTimeout         time.Duration `split_words:"true" default:"5s"`
+	PrefixMessage         string        `split_words:"true" default:"error gin server"`
+	CustomizedRender      bool          `split_words:"true" default:"false"`
+	AllowAllOrigins       bool          `split_words:"true" default:"false"`
+	AllowOrigins          []string      `split_words:"true" default:"http://localhost,https://localhost"`
+	AllowMethods          []string      `split_words:"true" default:"GET,POST,PUT,DELETE,OPTIONS"`
+	AllowHeaders          []string      `split_words:"true" default:"Origin,Upgrade,Content-Length,Content-Type,Authorization,Connection,Accept-Encoding,Accept-Language,Host,X-Google-*,X-AppEngine-*,X-CloudScheduler,X-CloudScheduler-JobName,X-CloudScheduler-ScheduleTime,Sec-WebSocket-Key,Sec-WebSocket-Version,Sec-WebSocket-Protocol"`
+	ExposeHeaders         []string      `split_words:"true" default:""`
+	AllowCredentials      bool          `split_words:"true" default:"false"`
+	CorsMaxAge            time.Duration `split_words:"true" default:"12h"`
+	SecurityHeaders       bool          `split_words:"true" default:"false"` // enable SecurityHeaders middleware
+	HSTSMaxAge            time.Duration `split_words:"true" default:"8760h"` // Strict-Transport-Security max-age, 0 disable the header
+	HSTSIncludeSubdomains bool          `split_words:"true" default:"true"`
+	ContentSecurityPolicy string        `split_words:"true" default:"default-src 'self'"`
+	FrameOptions          string        `split_words:"true" default:"DENY"`
+	ReferrerPolicy        string        `split_words:"true" default:"strict-origin-when-cross-origin"`
+	TrustedProxies        []string      `split_words:"true" default:""` // empty means ClientIP never trust forwarded headers, set to load balancer ranges
+	TrustedPlatform       string        `split_words:"true" default:""` // e.g. X-Appengine-Remote-Addr, CF-Connecting-IP
+	AllowedPaths          []string      `split_words:"true" default:"/favicon.ico,/ping,/ready,/metrics,/api/auth/v1/authorization,/narrow_cast_schedule"`
+	JWTGuard              bool          `split_words:"true" default:"true"`
+	MaxMultipartMemoryMB  int64         `split_words:"true" default:"8"`
+	LogLevelHandler       bool          `split_words:"true" default:"false"` // mount GET/PUT /debug/loglevel, guarded by JWTGuard unless allowed
+}
+
+// Validate method
+func (s Server) Validate() error {
+	return newValidation("Server").
+		check(validPort(s.Port), "Port %q is not a valid port", s.Port).
+		check(s.MetricsPort == "" || validPort(s.MetricsPort), "MetricsPort %q is not a valid port", s.MetricsPort).
+		check(s.ServerTimeout > 0, "ServerTimeout must be positive").
+		check(!(s.AllowAllOrigins && s.AllowCredentials), "AllowCredentials cannot be enabled with AllowAllOrigins").
+		check(s.AllowAllOrigins || len(s.AllowOrigins) > 0, "AllowOrigins is required when AllowAllOrigins disabled").
+		check(s.CorsMaxAge >= 0 && s.HSTSMaxAge >= 0, "CorsMaxAge and HSTSMaxAge must not be negative").
+		check(s.MaxMultipartMemoryMB > 0, "MaxMultipartMemoryMB must be positive").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (s Server) String() string {
+	return redact(s)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (s Server) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, s)
 }
@@ -12,17 +12,30 @@ import (
 
 type ServerSuite struct {
 	suite.Suite
-	ReleaseMode          bool
-	Port                 string
-	MetricsPort          string
-	ServerTimeout        time.Duration
-	PrefixMessage        string
-	CustomizedRender     bool
-	AllowAllOrigins      bool
-	AllowOrigins         []string
-	AllowedPaths         []string
-	JWTGuard             bool
-	MaxMultipartMemoryMB int64
+	ReleaseMode           bool
+	Port                  string
+	MetricsPort           string
+	ServerTimeout         time.Duration
+	PrefixMessage         string
+	CustomizedRender      bool
+	AllowAllOrigins       bool
+	AllowOrigins          []string
+	AllowMethods          []string
+	AllowHeaders          []string
+	ExposeHeaders         []string
+	AllowCredentials      bool
+	CorsMaxAge            time.Duration
+	SecurityHeaders       bool
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	ContentSecurityPolicy string
+	FrameOptions          string
+	ReferrerPolicy        string
+	TrustedProxies        []string
+	TrustedPlatform       string
+	AllowedPaths          []string
+	JWTGuard              bool
+	MaxMultipartMemoryMB  int64
 }
 
 func (suite *ServerSuite) SetupSuite() {
@@ -35,6 +48,19 @@ func (suite *ServerSuite) SetupSuite() {
 	suite.CustomizedRender = true
 	suite.AllowAllOrigins = true
 	suite.AllowOrigins = []string{"testAllowOrigins", "testAllowOrigins2"}
+	suite.AllowMethods = []string{"GET", "PATCH"}
+	suite.AllowHeaders = []string{"Authorization", "X-Request-ID"}
+	suite.ExposeHeaders = []string{"X-Request-ID"}
+	suite.AllowCredentials = true
+	suite.CorsMaxAge = time.Hour
+	suite.SecurityHeaders = true
+	suite.HSTSMaxAge = 24 * time.Hour
+	suite.HSTSIncludeSubdomains = false
+	suite.ContentSecurityPolicy = "default-src 'none'"
+	suite.FrameOptions = "SAMEORIGIN"
+	suite.ReferrerPolicy = "no-referrer"
+	suite.TrustedProxies = []string{"10.0.0.0/8", "192.168.0.1"}
+	suite.TrustedPlatform = "X-Appengine-Remote-Addr"
 	suite.AllowedPaths = []string{"/user/v1/login", "/user/v1/logout", "/user/v1/refresh_token"}
 	suite.JWTGuard = false
 	suite.MaxMultipartMemoryMB = 16
@@ -47,6 +73,19 @@ func (suite *ServerSuite) SetupSuite() {
 	suite.NoError(os.Setenv("CUSTOMIZED_RENDER", strconv.FormatBool(suite.CustomizedRender)))
 	suite.NoError(os.Setenv("ALLOW_ALL_ORIGINS", strconv.FormatBool(suite.AllowAllOrigins)))
 	suite.NoError(os.Setenv("ALLOW_ORIGINS", strings.Join(suite.AllowOrigins, ",")))
+	suite.NoError(os.Setenv("ALLOW_METHODS", strings.Join(suite.AllowMethods, ",")))
+	suite.NoError(os.Setenv("ALLOW_HEADERS", strings.Join(suite.AllowHeaders, ",")))
+	suite.NoError(os.Setenv("EXPOSE_HEADERS", strings.Join(suite.ExposeHeaders, ",")))
+	suite.NoError(os.Setenv("ALLOW_CREDENTIALS", strconv.FormatBool(suite.AllowCredentials)))
+	suite.NoError(os.Setenv("CORS_MAX_AGE", fmt.Sprint(suite.CorsMaxAge)))
+	suite.NoError(os.Setenv("SECURITY_HEADERS", strconv.FormatBool(suite.SecurityHeaders)))
+	suite.NoError(os.Setenv("HSTS_MAX_AGE", fmt.Sprint(suite.HSTSMaxAge)))
+	suite.NoError(os.Setenv("HSTS_INCLUDE_SUBDOMAINS", strconv.FormatBool(suite.HSTSIncludeSubdomains)))
+	suite.NoError(os.Setenv("CONTENT_SECURITY_POLICY", suite.ContentSecurityPolicy))
+	suite.NoError(os.Setenv("FRAME_OPTIONS", suite.FrameOptions))
+	suite.NoError(os.Setenv("REFERRER_POLICY", suite.ReferrerPolicy))
+	suite.NoError(os.Setenv("TRUSTED_PROXIES", strings.Join(suite.TrustedProxies, ",")))
+	suite.NoError(os.Setenv("TRUSTED_PLATFORM", suite.TrustedPlatform))
 	suite.NoError(os.Setenv("ALLOWED_PATHS", strings.Join(suite.AllowedPaths, ",")))
 	suite.NoError(os.Setenv("ALLOWED_PATHS", strings.Join(suite.AllowedPaths, ",")))
 	suite.NoError(os.Setenv("JWT_GUARD", strconv.FormatBool(suite.JWTGuard)))
@@ -64,11 +103,32 @@ func (suite *ServerSuite) TestDefaultOption() {
 	suite.Equal(suite.CustomizedRender, server.CustomizedRender)
 	suite.Equal(suite.AllowAllOrigins, server.AllowAllOrigins)
 	suite.Equal(suite.AllowOrigins, server.AllowOrigins)
+	suite.Equal(suite.AllowMethods, server.AllowMethods)
+	suite.Equal(suite.AllowHeaders, server.AllowHeaders)
+	suite.Equal(suite.ExposeHeaders, server.ExposeHeaders)
+	suite.Equal(suite.AllowCredentials, server.AllowCredentials)
+	suite.Equal(suite.CorsMaxAge, server.CorsMaxAge)
+	suite.Equal(suite.SecurityHeaders, server.SecurityHeaders)
+	suite.Equal(suite.HSTSMaxAge, server.HSTSMaxAge)
+	suite.Equal(suite.HSTSIncludeSubdomains, server.HSTSIncludeSubdomains)
+	suite.Equal(suite.ContentSecurityPolicy, server.ContentSecurityPolicy)
+	suite.Equal(suite.FrameOptions, server.FrameOptions)
+	suite.Equal(suite.ReferrerPolicy, server.ReferrerPolicy)
+	suite.Equal(suite.TrustedProxies, server.TrustedProxies)
+	suite.Equal(suite.TrustedPlatform, server.TrustedPlatform)
 	suite.Equal(suite.AllowedPaths, server.AllowedPaths)
 	suite.Equal(suite.JWTGuard, server.JWTGuard)
 	suite.Equal(suite.MaxMultipartMemoryMB, server.MaxMultipartMemoryMB)
 }
 
+func (suite *ServerSuite) TestTrustedProxiesDefault() {
+	suite.NoError(os.Unsetenv("TRUSTED_PROXIES"))
+	defer os.Setenv("TRUSTED_PROXIES", strings.Join(suite.TrustedProxies, ","))
+	server := &Server{}
+	suite.NoError(LoadFromEnv(server))
+	suite.Empty(server.TrustedProxies)
+}
+
 func TestServerSuite(t *testing.T) {
 	suite.Run(t, new(ServerSuite))
 }
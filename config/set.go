@@ -1,50 +1,139 @@
 package config
 
-func NewCassandra(set Set) Cassandra { return set.Cassandra }
-func NewCloud(set Set) Cloud         { return set.Cloud }
-func NewCockroach(set Set) Cockroach { return set.Cockroach }
-func NewCore(set Set) Core           { return set.Core }
-func NewFirebase(set Set) Firebase   { return set.Firebase }
-func NewGRPC(set Set) GRPC           { return set.GRPC }
-func NewJWT(set Set) JWT             { return set.JWT }
-func NewMongo(set Set) Mongo         { return set.Mongo }
-func NewPubSub(set Set) PubSub       { return set.PubSub }
-func NewPostgres(set Set) Postgres   { return set.Postgres }
-func NewRedis(set Set) Redis         { return set.Redis }
-func NewServer(set Set) Server       { return set.Server }
-func NewSpanner(set Set) Spanner     { return set.Spanner }
+import (
+	"errors"
+	"go.uber.org/zap/zapcore"
+	"io"
+	"reflect"
+)
+
+func NewCassandra(set Set) Cassandra   { return set.Cassandra }
+func NewCloud(set Set) Cloud           { return set.Cloud }
+func NewCockroach(set Set) Cockroach   { return set.Cockroach }
+func NewCore(set Set) Core             { return set.Core }
+func NewFirebase(set Set) Firebase     { return set.Firebase }
+func NewFirestore(set Set) Firestore   { return set.Firestore }
+func NewGRPC(set Set) GRPC             { return set.GRPC }
+func NewJWT(set Set) JWT               { return set.JWT }
+func NewMongo(set Set) Mongo           { return set.Mongo }
+func NewPubSub(set Set) PubSub         { return set.PubSub }
+func NewPostgres(set Set) Postgres     { return set.Postgres }
+func NewPostgresql(set Set) Postgresql { return set.Postgresql }
+func NewRedis(set Set) Redis           { return set.Redis }
+func NewServer(set Set) Server         { return set.Server }
+func NewSpanner(set Set) Spanner       { return set.Spanner }
 func NewSet() (Set, error) {
 	set := Set{}
-	err := LoadFromEnv(
-		&set.Cassandra,
-		&set.Cloud,
-		&set.Cockroach,
-		&set.Core,
-		&set.Firebase,
-		&set.GRPC,
-		&set.JWT,
-		&set.Mongo,
-		&set.PubSub,
-		&set.Postgres,
-		&set.Redis,
-		&set.Server,
-		&set.Spanner,
-	)
-	return set, err
+	if err := LoadFromEnv(set.pointers()...); err != nil {
+		return set, err
+	}
+	return set, set.Validate()
+}
+
+// Load method
+// loads and validates only sections selected by section options (e.g. WithRedis, WithServer),
+// all sections when none selected, Loader options (e.g. WithFile, WithPrefix) are accepted too
+func Load(options ...LoaderOption) (Set, error) {
+	return NewLoader(options...).LoadSet()
+}
+
+// LoadSet method
+// loads and validates sections selected by Loader, unselected sections are left zero
+func (l *Loader) LoadSet() (Set, error) {
+	set := Set{}
+	pointers := l.pointers(&set)
+	if err := l.Load(pointers...); err != nil {
+		return set, err
+	}
+	return set, validate(pointers)
+}
+
+// PrintSet method
+// writes sections of set selected by Loader, see Print
+func (l *Loader) PrintSet(w io.Writer, set Set) error {
+	return l.Print(w, l.pointers(&set)...)
+}
+
+// Loaded method
+// reports whether section (type name, e.g. "Redis") is selected, all sections are selected when none selected
+func (l *Loader) Loaded(name string) bool {
+	if len(l.sections) == 0 {
+		return true
+	}
+	for _, section := range l.sections {
+		if section == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Loader) pointers(s *Set) []interface{} {
+	pointers := make([]interface{}, 0, len(sections))
+	for _, section := range sections {
+		if l.Loaded(section.name) {
+			pointers = append(pointers, section.pointer(s))
+		}
+	}
+	return pointers
+}
+
+func (s *Set) pointers() []interface{} {
+	pointers := make([]interface{}, 0, len(sections))
+	for _, section := range sections {
+		pointers = append(pointers, section.pointer(s))
+	}
+	return pointers
 }
 
 type Set struct {
-	Cassandra Cassandra
-	Cloud     Cloud
-	Cockroach Cockroach
-	Core      Core
-	Firebase  Firebase
-	GRPC      GRPC
-	JWT       JWT
-	Mongo     Mongo
-	PubSub    PubSub
-	Postgres  Postgres
-	Redis     Redis
-	Server    Server
-	Spanner   Spanner
+	Cassandra  Cassandra
+	Cloud      Cloud
+	Cockroach  Cockroach
+	Core       Core
+	Firebase   Firebase
+	Firestore  Firestore
+	GRPC       GRPC
+	JWT        JWT
+	Mongo      Mongo
+	PubSub     PubSub
+	Postgres   Postgres
+	Postgresql Postgresql
+	Redis      Redis
+	Server     Server
+	Spanner    Spanner
+}
+
+// Print method
+// writes effective configuration as KEY=value lines with secret fields masked, options give key prefix
+func (s *Set) Print(w io.Writer, options ...LoaderOption) error {
+	return NewLoader(options...).Print(w, s.pointers()...)
+}
+
+// Validate method
+// validates every section, returns all violations joined
+func (s *Set) Validate() error {
+	return validate(s.pointers())
+}
+
+func validate(pointers []interface{}) error {
+	errs := make([]error, 0)
+	for _, pointer := range pointers {
+		if err := pointer.(interface{ Validate() error }).Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (s Set) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, pointer := range s.pointers() {
+		section := reflect.ValueOf(pointer).Elem()
+		if err := enc.AddObject(section.Type().Name(), section.Interface().(zapcore.ObjectMarshaler)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"bytes"
 	"github.com/stretchr/testify/suite"
+	"os"
 	"reflect"
 	"testing"
 )
@@ -10,6 +12,18 @@ type ConfigSetSuite struct {
 	suite.Suite
 }
 
+// SetupTest clears variables leaked by section suites
+func (suite *ConfigSetSuite) SetupTest() {
+	set := Set{}
+	for _, pointer := range set.pointers() {
+		infos, err := gatherInfo(pointer)
+		suite.Require().NoError(err)
+		for _, info := range infos {
+			suite.Require().NoError(os.Unsetenv(info.Key))
+		}
+	}
+}
+
 func (suite *ConfigSetSuite) TestNewConfigSet() {
 	result, err := NewSet()
 	suite.NoError(err)
@@ -94,6 +108,81 @@ func (suite *ConfigSetSuite) TestNewSpanner() {
 	suite.Equal("Spanner", reflect.TypeOf(NewSpanner(result)).Name())
 }
 
+func (suite *ConfigSetSuite) TestNewFirestore() {
+	result, err := NewSet()
+	suite.NoError(err)
+	suite.Equal("Firestore", reflect.TypeOf(NewFirestore(result)).Name())
+}
+
+func (suite *ConfigSetSuite) TestNewPostgresql() {
+	result, err := NewSet()
+	suite.NoError(err)
+	suite.Equal("Postgresql", reflect.TypeOf(NewPostgresql(result)).Name())
+	suite.Equal("localhost", result.Postgresql.PostgresqlHost)
+}
+
+func (suite *ConfigSetSuite) TestLoad() {
+	suite.T().Setenv("SVC_REDIS_HOST", "redis.svc")
+	// invalid but not selected sections are neither loaded nor validated
+	suite.T().Setenv("CASSANDRA_PORT", "abc")
+	suite.T().Setenv("PROJECT_ID", "")
+	loader := NewLoader(WithRedis(), WithServer(), WithRedis(), WithPrefix("svc"))
+	result, err := loader.LoadSet()
+	suite.NoError(err)
+	suite.Equal("redis.svc", result.Redis.RedisHost)
+	suite.Equal("38080", result.Server.Port)
+	suite.Equal("", result.Cassandra.CassandraPort)
+	suite.True(loader.Loaded("Redis"))
+	suite.False(loader.Loaded("Cassandra"))
+	suite.Len(loader.pointers(&result), 2)
+	suite.Len(result.pointers(), len(sections))
+
+	buf := &bytes.Buffer{}
+	suite.NoError(loader.PrintSet(buf, result))
+	suite.Contains(buf.String(), "SVC_REDIS_HOST=redis.svc\n")
+	suite.NotContains(buf.String(), "CASSANDRA")
+
+	loaded, err := Load(WithRedis(), WithServer(), WithPrefix("svc"))
+	suite.NoError(err)
+	suite.Equal(result, loaded)
+
+	_, err = Load(WithCassandra())
+	suite.Error(err)
+	_, err = Load(WithSpanner())
+	suite.ErrorIs(err, ErrInvalidConfig)
+}
+
+func (suite *ConfigSetSuite) TestLoadAllSections() {
+	result, err := Load()
+	suite.NoError(err)
+	suite.Len(NewLoader().pointers(&result), len(sections))
+	for _, option := range []LoaderOption{
+		WithCassandra(), WithCloud(), WithCockroach(), WithCore(), WithFirebase(), WithFirestore(), WithGRPC(), WithJWT(),
+		WithMongo(), WithPubSub(), WithPostgres(), WithPostgresql(), WithRedis(), WithServer(), WithSpanner(),
+	} {
+		_, err := Load(option)
+		suite.NoError(err)
+		suite.Len(NewLoader(option).pointers(&result), 1)
+	}
+}
+
+func (suite *ConfigSetSuite) TestLoadWithPrefix() {
+	suite.T().Setenv("SVC_REDIS_HOST", "redis.svc")
+	result, err := Load(WithPrefix("svc"))
+	suite.NoError(err)
+	suite.Equal("redis.svc", result.Redis.RedisHost)
+	suite.Equal("6379", result.Redis.RedisPort)
+}
+
+func (suite *ConfigSetSuite) TestSetPrint() {
+	result, err := NewSet()
+	suite.NoError(err)
+	buf := &bytes.Buffer{}
+	suite.NoError(result.Print(buf, WithPrefix("svc")))
+	suite.Contains(buf.String(), "SVC_REDIS_HOST=localhost\n")
+	suite.Contains(buf.String(), "SVC_POSTGRES_PASSWORD=******\n")
+}
+
 func TestConfigSetSuite(t *testing.T) {
 	suite.Run(t, new(ConfigSetSuite))
 }
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	readFile = os.ReadFile
+)
+
+// readFiles reads config files into normalized key values,
+// keys are matched case-insensitively ignoring "_" and "-", nested objects are joined by "_",
+// e.g. `redis: {host: localhost}` and `REDIS_HOST: localhost` both set REDIS_HOST
+func readFiles(paths []string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, path := range paths {
+		raw, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		content := make(map[string]interface{})
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml":
+			err = yaml.Unmarshal(raw, &content)
+		case ".json":
+			decoder := json.NewDecoder(bytes.NewReader(raw))
+			decoder.UseNumber()
+			err = decoder.Decode(&content)
+		case ".toml":
+			err = toml.Unmarshal(raw, &content)
+		default:
+			return nil, fmt.Errorf("unsupported config file format: %s", path)
+		}
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("parse config file %s: %w", path, err)
+		}
+		flatten("", content, values)
+	}
+	return values, nil
+}
+
+func flatten(prefix string, content map[string]interface{}, values map[string]string) {
+	for key, value := range content {
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flatten(key, v, values)
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[normalizeKey(key)] = strings.Join(items, ",")
+		case nil:
+			values[normalizeKey(key)] = ""
+		default:
+			values[normalizeKey(key)] = fmt.Sprint(v)
+		}
+	}
+}
+
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
+
+// readDotEnvs reads KEY=VALUE lines, supports comments, `export` and quoted values
+func readDotEnvs(paths []string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, path := range paths {
+		raw, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(raw))
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			text = strings.TrimPrefix(text, "export ")
+			key, value, found := strings.Cut(text, "=")
+			if !found {
+				return nil, fmt.Errorf("parse .env file %s line %d: missing '='", path, line)
+			}
+			value, err := unquote(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("parse .env file %s line %d: %w", path, line, err)
+			}
+			values[strings.TrimSpace(key)] = value
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func unquote(value string) (string, error) {
+	if len(value) >= 2 {
+		switch value[0] {
+		case '"':
+			if value[len(value)-1] == '"' {
+				return strconv.Unquote(value)
+			}
+		case '\'':
+			if value[len(value)-1] == '\'' {
+				return value[1 : len(value)-1], nil
+			}
+		}
+	}
+	if index := strings.Index(value, " #"); index >= 0 {
+		value = strings.TrimSpace(value[:index])
+	}
+	return value, nil
+}
+
+func flagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+// parseArgs parses command-line flags of fields, returns set flags by flag name,
+// unknown flags and positional arguments are ignored, parsing stops at "--"
+func parseArgs(args []string, infos []fieldInfo) (map[string]string, error) {
+	values := make(map[string]string)
+	bools := make(map[string]bool)
+	for _, info := range infos {
+		bools[flagName(info.Key)] = info.Field.Kind() == reflect.Bool
+	}
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--" {
+			break
+		}
+		if len(args[i]) < 2 || args[i][0] != '-' {
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(args[i][1:], "-"), "=")
+		isBool, ok := bools[name]
+		if !ok {
+			continue
+		}
+		switch {
+		case hasValue:
+		case isBool:
+			value = "true"
+		case i+1 < len(args):
+			i++
+			value = args[i]
+		default:
+			return nil, fmt.Errorf("flag needs an argument: -%s", name)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SourceSuite struct {
+	suite.Suite
+}
+
+func (suite *SourceSuite) stubFile(content string) func() {
+	return gostub.StubFunc(&readFile, []byte(content), nil).Reset
+}
+
+func (suite *SourceSuite) TestReadFilesYAML() {
+	defer suite.stubFile("redis:\n  host: yaml\nALLOW_ORIGINS:\n  - a\n  - b\nempty:\n")()
+	values, err := readFiles([]string{"config.yml"})
+	suite.NoError(err)
+	suite.Equal(map[string]string{"redishost": "yaml", "alloworigins": "a,b", "empty": ""}, values)
+}
+
+func (suite *SourceSuite) TestReadFilesJSON() {
+	defer suite.stubFile(`{"redis": {"redis-port": 6379}, "ratio": 0.5}`)()
+	values, err := readFiles([]string{"config.json"})
+	suite.NoError(err)
+	suite.Equal(map[string]string{"redisredisport": "6379", "ratio": "0.5"}, values)
+}
+
+func (suite *SourceSuite) TestReadFilesTOML() {
+	defer suite.stubFile("[redis]\nhost = \"toml\"\npool_size = 10\n")()
+	values, err := readFiles([]string{"config.toml"})
+	suite.NoError(err)
+	suite.Equal(map[string]string{"redishost": "toml", "redispoolsize": "10"}, values)
+}
+
+func (suite *SourceSuite) TestReadFilesError() {
+	defer suite.stubFile("{")()
+	_, err := readFiles([]string{"config.json"})
+	suite.Error(err)
+	_, err = readFiles([]string{"config.ini"})
+	suite.EqualError(err, "unsupported config file format: config.ini")
+
+	defer gostub.StubFunc(&readFile, nil, errors.New("got error")).Reset()
+	_, err = readFiles([]string{"config.yaml"})
+	suite.Error(err)
+}
+
+func (suite *SourceSuite) TestReadDotEnvs() {
+	defer suite.stubFile("# comment\n\nexport A=1\nB = two # note\nC='single # quoted'\nD=\"double\\tquoted\"\nE=\n")()
+	values, err := readDotEnvs([]string{".env"})
+	suite.NoError(err)
+	suite.Equal(map[string]string{"A": "1", "B": "two", "C": "single # quoted", "D": "double\tquoted", "E": ""}, values)
+}
+
+func (suite *SourceSuite) TestReadDotEnvsError() {
+	defer suite.stubFile("INVALID\n")()
+	_, err := readDotEnvs([]string{".env"})
+	suite.EqualError(err, "parse .env file .env line 1: missing '='")
+}
+
+func (suite *SourceSuite) TestParseArgs() {
+	infos, err := gatherInfo(&GRPC{})
+	suite.Require().NoError(err)
+	values, err := parseArgs([]string{"--port", "9000", "--unknown", "--no-tls=false", "-alts", "rest", "--", "--tls"}, infos)
+	suite.NoError(err)
+	suite.Equal(map[string]string{"port": "9000", "no-tls": "false", "alts": "true"}, values)
+
+	_, err = parseArgs([]string{"--port"}, infos)
+	suite.EqualError(err, "flag needs an argument: -port")
+}
+
+func TestSourceSuite(t *testing.T) {
+	suite.Run(t, new(SourceSuite))
+}
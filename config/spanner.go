@@ -1,5 +1,7 @@
 package config
 
+import "go.uber.org/zap/zapcore"
+
 // Spanner type
 type Spanner struct {
 	ProjectID             string `split_words:"true" default:"test-project"`
@@ -9,3 +11,24 @@ type Spanner struct {
 	WithoutAuthentication bool   `split_words:"true" default:"true"`
 	GRPCInsecure          bool   `split_words:"true" default:"true"`
 }
+
+// Validate method
+func (s Spanner) Validate() error {
+	return newValidation("Spanner").
+		check(s.ProjectID != "", "ProjectID is required").
+		check(s.Instance != "", "Instance is required").
+		check(s.Database != "", "Database is required").
+		err()
+}
+
+// String method
+// secret fields are masked
+func (s Spanner) String() string {
+	return redact(s)
+}
+
+// MarshalLogObject method
+// implements zapcore.ObjectMarshaler, secret fields are masked
+func (s Spanner) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalLogObject(enc, s)
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap/zapcore"
+	"net/url"
+	"strconv"
+)
+
+var (
+	// ErrInvalidConfig config section validation failed
+	ErrInvalidConfig = errors.New("invalid config")
+)
+
+// validation collects rule violations of a config section
+type validation struct {
+	section string
+	errs    []error
+}
+
+func newValidation(section string) *validation {
+	return &validation{section: section}
+}
+
+// check records message when ok is false
+func (v *validation) check(ok bool, format string, args ...interface{}) *validation {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("%w: %s: %s", ErrInvalidConfig, v.section, fmt.Sprintf(format, args...)))
+	}
+	return v
+}
+
+func (v *validation) err() error {
+	return errors.Join(v.errs...)
+}
+
+func validPort(port string) bool {
+	p, err := strconv.ParseUint(port, 10, 16)
+	return err == nil && p <= 65535
+}
+
+func validURL(raw string) bool {
+	if raw == "" {
+		return true
+	}
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+func validJSON(raw string) bool {
+	return raw == "" || json.Valid([]byte(raw))
+}
+
+func validBase64(raw string) bool {
+	if raw == "" {
+		return true
+	}
+	_, err := base64.StdEncoding.DecodeString(raw)
+	return err == nil
+}
+
+func validLevel(level string) bool {
+	_, err := zapcore.ParseLevel(level)
+	return err == nil
+}
+
+func validNamedLevels(levels map[string]string) bool {
+	for name, level := range levels {
+		if name == "" || !validLevel(level) {
+			return false
+		}
+	}
+	return true
+}
+
+func validEncoding(encoding string) bool {
+	return encoding == "" || encoding == "json" || encoding == "console" || encoding == "gcp"
+}
+
+func validSinks(sinks []string) bool {
+	for _, sink := range sinks {
+		u, err := url.Parse(sink)
+		if err != nil || !validEncoding(u.Query().Get("encoding")) {
+			return false
+		}
+		switch u.Scheme {
+		case "":
+			if u.Path != "stdout" && u.Path != "stderr" {
+				return false
+			}
+		case "file":
+			if u.Path == "" && u.Opaque == "" {
+				return false
+			}
+		case "udp":
+			if u.Host == "" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func countTrue(values ...bool) int {
+	count := 0
+	for _, value := range values {
+		if value {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ValidateSuite struct {
+	suite.Suite
+	set Set
+}
+
+func (suite *ValidateSuite) SetupTest() {
+	set := Set{}
+	suite.Require().NoError(NewLoader().Load(set.pointers()...))
+	suite.set = set
+}
+
+func (suite *ValidateSuite) TestDefaultValid() {
+	suite.NoError(suite.set.Validate())
+	suite.NoError(Firestore{}.Validate())
+	postgresql := Postgresql{}
+	suite.NoError(NewLoader().Load(&postgresql))
+	suite.NoError(postgresql.Validate())
+}
+
+func (suite *ValidateSuite) TestInvalid() {
+	cases := map[string]interface{ Validate() error }{
+		"invalid config: Cassandra: CassandraForcePassword requires CassandraUsername and CassandraPassword": func() Cassandra {
+			c := suite.set.Cassandra
+			c.CassandraForcePassword, c.CassandraPassword = true, ""
+			return c
+		}(),
+		"invalid config: Cloud: ProjectID is required": Cloud{},
+		"invalid config: Cockroach: CockroachURL is not a valid URL": func() Cockroach {
+			c := suite.set.Cockroach
+			c.CockroachURL = "localhost"
+			return c
+		}(),
+		"invalid config: Core: LoggerMode \"debug\" must be development, production or customized": Core{LoggerMode: "debug", SystemName: "system"},
+		"invalid config: Core: LoggerLevel \"verbose\" is not a valid level":                       Core{LoggerMode: "customized", LoggerLevel: "verbose", SystemName: "system"},
+		"invalid config: Core: LoggerEncoding \"xml\" must be json, console or gcp":                Core{LoggerMode: "customized", LoggerEncoding: "xml", SystemName: "system"},
+		"invalid config: Core: LoggerSinks [stdout tcp://localhost:514] contains invalid sink":     Core{LoggerMode: "customized", LoggerSinks: []string{"stdout", "tcp://localhost:514"}, SystemName: "system"},
+		"invalid config: Core: LoggerNamedLevels map[database:verbose] contains invalid level":     Core{LoggerMode: "customized", LoggerNamedLevels: map[string]string{"database": "verbose"}, SystemName: "system"},
+		"invalid config: Firebase: FirebaseConfigJSON is not valid JSON":                           Firebase{FirebaseConfigJSON: "{"},
+		"invalid config: Firestore: ProjectID is required when EndPoint set":                       Firestore{EndPoint: "localhost:8080"},
+		"invalid config: GRPC: only one of SkipTLS, TLS, TLSNil and ALTS can be enabled": func() GRPC {
+			g := suite.set.GRPC
+			g.TLS, g.TLSPemCert, g.ALTS = true, "cert", true
+			return g
+		}(),
+		"invalid config: GRPC: TLS requires TLSPemCert or TLSPemCertBase64": func() GRPC {
+			g := suite.set.GRPC
+			g.TLS = true
+			return g
+		}(),
+		"invalid config: GRPC: server TLS requires both certificate and key": GRPC{Port: "38080", ServerTLSCert: "cert"},
+		"invalid config: GRPC: only one of ServerTLSCert, ServerTLSCertBase64 and ServerTLSCertFile can be set": GRPC{
+			Port: "38080", ServerTLSCert: "cert", ServerTLSCertFile: "cert.pem", ServerTLSKey: "key",
+		},
+		"invalid config: GRPC: ServerTLSClientCA requires server TLS certificate": GRPC{Port: "38080", ServerTLSClientCAFile: "ca.pem"},
+		"invalid config: GRPC: server TLS and ALTS cannot be enabled together": GRPC{
+			Port: "38080", ALTS: true, ServerTLSCertFile: "cert.pem", ServerTLSKeyFile: "key.pem",
+		},
+		"invalid config: GRPC: server TLS base64 data is not valid base64": GRPC{
+			Port: "38080", ServerTLSCertFile: "cert.pem", ServerTLSKeyBase64: "!",
+		},
+		"invalid config: JWT: MetadataClientIDKey is required":                         JWT{},
+		"invalid config: Mongo: MongoProtocol \"http\" must be mongodb or mongodb+srv": Mongo{MongoProtocol: "http"},
+		"invalid config: Postgres: PostgresPort \"abc\" is not a valid port": func() Postgres {
+			p := suite.set.Postgres
+			p.PostgresURL, p.PostgresPort = "", "abc"
+			return p
+		}(),
+		"invalid config: Postgresql: PostgresqlHost is required when PostgresqlURL not set": Postgresql{PostgresqlPort: "5432"},
+		"invalid config: PubSub: ProjectID is required":                                     PubSub{},
+		"invalid config: Redis: RedisPoolSize must be positive": func() Redis {
+			r := suite.set.Redis
+			r.RedisPoolSize = 0
+			return r
+		}(),
+		"invalid config: Server: AllowCredentials cannot be enabled with AllowAllOrigins": func() Server {
+			s := suite.set.Server
+			s.AllowAllOrigins, s.AllowCredentials = true, true
+			return s
+		}(),
+		"invalid config: Spanner: Database is required": Spanner{ProjectID: "project", Instance: "instance"},
+	}
+	for message, section := range cases {
+		err := section.Validate()
+		suite.ErrorIs(err, ErrInvalidConfig, message)
+		suite.Contains(err.Error(), message)
+	}
+}
+
+func (suite *ValidateSuite) TestTLSOverridesDefaultNoTLS() {
+	g := GRPC{}
+	suite.NoError(NewLoader(WithArgs([]string{"--tls", "--tls-pem-cert=cert"})).Load(&g))
+	suite.True(g.NoTLS)
+	suite.NoError(g.Validate())
+	suite.False(g.Insecure())
+	suite.True(suite.set.GRPC.Insecure())
+}
+
+func (suite *ValidateSuite) TestSetValidateJoinsErrors() {
+	suite.set.Redis.RedisPort = "abc"
+	suite.set.GRPC.Port = "99999"
+	err := suite.set.Validate()
+	suite.ErrorIs(err, ErrInvalidConfig)
+	suite.Contains(err.Error(), "invalid config: Redis: RedisPort \"abc\" is not a valid port")
+	suite.Contains(err.Error(), "invalid config: GRPC: Port \"99999\" is not a valid port")
+}
+
+func (suite *ValidateSuite) TestHelpers() {
+	suite.True(validPort("0"))
+	suite.False(validPort("-1"))
+	suite.True(validURL(""))
+	suite.False(validURL("://"))
+	suite.True(validJSON(`{"a":1}`))
+	suite.False(validBase64("!"))
+	suite.Equal(2, countTrue(true, false, true))
+	suite.True(validLevel("warn"))
+	suite.False(validLevel("verbose"))
+	suite.True(validSinks([]string{"stdout", "stderr", "file:///var/log/app.log", "file:app.log?encoding=console", "udp://localhost:514?encoding=gcp"}))
+	suite.False(validSinks([]string{"stdin"}))
+	suite.False(validSinks([]string{"file://"}))
+	suite.False(validSinks([]string{"udp:///path"}))
+	suite.False(validSinks([]string{"stdout?encoding=xml"}))
+}
+
+func TestValidateSuite(t *testing.T) {
+	suite.Run(t, new(ValidateSuite))
+}
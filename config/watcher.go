@@ -0,0 +1,212 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	statFile = os.Stat
+)
+
+// WatcherOption interface
+type WatcherOption interface {
+	Apply(*Watcher)
+}
+
+// WithWatchInterval method
+// polling interval of loader files modification, 0 disables file watching, default 5s
+func WithWatchInterval(interval time.Duration) WatcherOption {
+	return withWatchInterval{interval: interval}
+}
+
+type withWatchInterval struct {
+	interval time.Duration
+}
+
+// Apply method
+func (w withWatchInterval) Apply(watcher *Watcher) {
+	watcher.interval = w.interval
+}
+
+// WithWatchSignals method
+// signals trigger reload, default SIGHUP
+func WithWatchSignals(signals ...os.Signal) WatcherOption {
+	return withWatchSignals{signals: signals}
+}
+
+type withWatchSignals struct {
+	signals []os.Signal
+}
+
+// Apply method
+func (w withWatchSignals) Apply(watcher *Watcher) {
+	watcher.signals = w.signals
+}
+
+// WithReloadErrorHandler method
+// receives load or validation error of reload, current Set is kept
+func WithReloadErrorHandler(fn func(err error)) WatcherOption {
+	return withReloadErrorHandler{fn: fn}
+}
+
+type withReloadErrorHandler struct {
+	fn func(err error)
+}
+
+// Apply method
+func (w withReloadErrorHandler) Apply(watcher *Watcher) {
+	watcher.errorHandler = w.fn
+}
+
+// Watcher type
+// reloads Set by Loader on signal or file change, validates then notifies subscribers
+type Watcher struct {
+	loader       *Loader
+	interval     time.Duration
+	signals      []os.Signal
+	errorHandler func(err error)
+
+	mu          sync.RWMutex
+	current     Set
+	subscribers []func(old, new Set)
+	modTimes    map[string]time.Time
+}
+
+// NewWatcher method
+// loads and validates the initial Set
+func NewWatcher(loader *Loader, options ...WatcherOption) (*Watcher, error) {
+	w := &Watcher{
+		loader:       loader,
+		interval:     5 * time.Second,
+		signals:      []os.Signal{syscall.SIGHUP},
+		errorHandler: func(err error) {},
+	}
+	for _, option := range options {
+		option.Apply(w)
+	}
+	set, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.current = set
+	w.modTimes = w.stat()
+	return w, nil
+}
+
+// Current method
+func (w *Watcher) Current() Set {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// OnChange method
+// fn is called with old and new Set after every successful reload which changed Set
+func (w *Watcher) OnChange(fn func(old, new Set)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Subscribe method
+// fn is called with the selected value when it changed, e.g.
+//
+//	config.Subscribe(watcher, config.NewServer, func(server config.Server) {...})
+func Subscribe[T any](w *Watcher, selector func(Set) T, fn func(T)) {
+	w.OnChange(func(old, new Set) {
+		value := selector(new)
+		if !reflect.DeepEqual(selector(old), value) {
+			fn(value)
+		}
+	})
+}
+
+// Reload method
+// loads and validates Set, keeps current Set and returns error when failed
+func (w *Watcher) Reload() error {
+	set, err := w.load()
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	old := w.current
+	w.current = set
+	subscribers := make([]func(old, new Set), len(w.subscribers))
+	copy(subscribers, w.subscribers)
+	w.mu.Unlock()
+
+	if reflect.DeepEqual(old, set) {
+		return nil
+	}
+	for _, subscriber := range subscribers {
+		subscriber(old, set)
+	}
+	return nil
+}
+
+// Run method
+// blocks and reloads on signal or file change until ctx done
+func (w *Watcher) Run(ctx context.Context) {
+	sig := make(chan os.Signal, 1)
+	if len(w.signals) > 0 {
+		signal.Notify(sig, w.signals...)
+		defer signal.Stop(sig)
+	}
+	var tick <-chan time.Time
+	if w.interval > 0 {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sig:
+			w.reload()
+		case <-tick:
+			if w.changed() {
+				w.reload()
+			}
+		}
+	}
+}
+
+func (w *Watcher) reload() {
+	if err := w.Reload(); err != nil {
+		w.errorHandler(err)
+	}
+}
+
+func (w *Watcher) load() (Set, error) {
+	return w.loader.LoadSet()
+}
+
+func (w *Watcher) files() []string {
+	return append(append([]string{}, w.loader.files...), w.loader.dotEnvs...)
+}
+
+func (w *Watcher) stat() map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+	for _, file := range w.files() {
+		if info, err := statFile(file); err == nil {
+			modTimes[file] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+func (w *Watcher) changed() bool {
+	modTimes := w.stat()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	changed := !reflect.DeepEqual(w.modTimes, modTimes)
+	w.modTimes = modTimes
+	return changed
+}
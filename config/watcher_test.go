@@ -0,0 +1,119 @@
+package config
+
+import (
+	"context"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type WatcherSuite struct {
+	suite.Suite
+	file string
+}
+
+func (suite *WatcherSuite) SetupTest() {
+	stubs := gostub.Stub(&lookupEnv, func(key string) (string, bool) { return "", false })
+	suite.T().Cleanup(stubs.Reset)
+	suite.file = filepath.Join(suite.T().TempDir(), "config.yaml")
+	suite.write("allowed_paths: [/ping]\n", time.Now().Add(-time.Minute))
+}
+
+func (suite *WatcherSuite) write(content string, modTime time.Time) {
+	suite.Require().NoError(os.WriteFile(suite.file, []byte(content), 0o600))
+	suite.Require().NoError(os.Chtimes(suite.file, modTime, modTime))
+}
+
+func (suite *WatcherSuite) TestNewWatcher() {
+	w, err := NewWatcher(NewLoader(WithFile(suite.file)))
+	suite.NoError(err)
+	suite.Equal([]string{"/ping"}, w.Current().Server.AllowedPaths)
+	suite.Equal(5*time.Second, w.interval)
+}
+
+func (suite *WatcherSuite) TestNewWatcherError() {
+	suite.write("port: abc\n", time.Now())
+	_, err := NewWatcher(NewLoader(WithFile(suite.file)))
+	suite.ErrorIs(err, ErrInvalidConfig)
+
+	_, err = NewWatcher(NewLoader(WithFile(filepath.Join(filepath.Dir(suite.file), "missing.yaml"))))
+	suite.Error(err)
+}
+
+func (suite *WatcherSuite) TestReload() {
+	w, err := NewWatcher(NewLoader(WithFile(suite.file)))
+	suite.Require().NoError(err)
+
+	changes, paths := 0, make([]string, 0)
+	w.OnChange(func(old, new Set) { changes++ })
+	Subscribe(w, func(set Set) []string { return set.Server.AllowedPaths }, func(value []string) { paths = value })
+	Subscribe(w, NewRedis, func(value Redis) { suite.Fail("redis not changed") })
+
+	suite.NoError(w.Reload())
+	suite.Equal(0, changes)
+
+	suite.write("allowed_paths: [/ping, /metrics]\n", time.Now())
+	suite.NoError(w.Reload())
+	suite.Equal(1, changes)
+	suite.Equal([]string{"/ping", "/metrics"}, paths)
+	suite.Equal([]string{"/ping", "/metrics"}, w.Current().Server.AllowedPaths)
+
+	suite.write("allowed_paths: [/]\nredis_port: abc\n", time.Now())
+	suite.ErrorIs(w.Reload(), ErrInvalidConfig)
+	suite.Equal([]string{"/ping", "/metrics"}, w.Current().Server.AllowedPaths)
+}
+
+func (suite *WatcherSuite) TestRunFileChange() {
+	mu := sync.Mutex{}
+	var paths []string
+	w, err := NewWatcher(NewLoader(WithFile(suite.file)), WithWatchInterval(10*time.Millisecond), WithWatchSignals())
+	suite.Require().NoError(err)
+	Subscribe(w, NewServer, func(server Server) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = server.AllowedPaths
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	suite.write("allowed_paths: [/metrics]\n", time.Now())
+	suite.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(paths) == 1 && paths[0] == "/metrics"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (suite *WatcherSuite) TestRunSignal() {
+	errs := make(chan error, 1)
+	w, err := NewWatcher(
+		NewLoader(WithFile(suite.file)),
+		WithWatchInterval(0),
+		WithWatchSignals(syscall.SIGUSR1),
+		WithReloadErrorHandler(func(err error) { errs <- err }),
+	)
+	suite.Require().NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+	time.Sleep(10 * time.Millisecond)
+
+	suite.write("port: abc\n", time.Now())
+	suite.Require().NoError(syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+	select {
+	case err := <-errs:
+		suite.ErrorIs(err, ErrInvalidConfig)
+	case <-time.After(time.Second):
+		suite.Fail("reload not triggered")
+	}
+}
+
+func TestWatcherSuite(t *testing.T) {
+	suite.Run(t, new(WatcherSuite))
+}
@@ -1,6 +1,7 @@
 package bunt
 
 import (
+	"context"
 	"github.com/justdomepaul/toolbox/errorhandler"
 	"github.com/tidwall/buntdb"
 	"go.uber.org/zap"
@@ -31,7 +32,7 @@ var NewSession = func(path string) (ISession, error) {
 	return buntdb.Open(path)
 }
 
-func NewExtendBuntDatabase(logger *zap.Logger) (ISession, func(), error) {
+func NewExtendBuntDatabase(logger *zap.Logger) (ISession, func(context.Context) error, error) {
 	session, err := NewSession(":memory:")
 	if err != nil {
 		return nil, nil, err
@@ -39,10 +40,10 @@ func NewExtendBuntDatabase(logger *zap.Logger) (ISession, func(), error) {
 	logger.Info("Bunt init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
+		func(ctx context.Context) error {
 			if err := session.Close(); err != nil {
-				panic(errorhandler.NewErrDBDisconnection(err))
+				return errorhandler.NewErrDBDisconnection(err)
 			}
+			return nil
 		}, nil
 }
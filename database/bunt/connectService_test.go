@@ -1,6 +1,7 @@
 package bunt
 
 import (
+	"context"
 	"github.com/cockroachdb/errors"
 	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/mock"
@@ -33,15 +34,15 @@ func (suite *ConnectServiceSuite) TestCreateSession() {
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendBuntDatabase() {
-	defer gostub.StubFunc(&NewSession, new(testISession), nil).Reset()
+	session := new(testISession)
+	session.On("Close").Return(nil)
+	defer gostub.StubFunc(&NewSession, session, nil).Reset()
 
 	result, fn, err := NewExtendBuntDatabase(zap.NewExample())
 	suite.NoError(err)
 	suite.Equal("*bunt.testISession", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
-	suite.NotPanics(func() {
-		fn()
-	})
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
+	suite.NoError(fn(context.Background()))
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendBuntDatabaseNewSessionError() {
@@ -62,7 +63,7 @@ func (suite *ConnectServiceSuite) TestNewExtendBuntDatabaseSessionCloseError() {
 
 	_, fn, err := NewExtendBuntDatabase(logger)
 	suite.NoError(err)
-	fn()
+	suite.Error(fn(context.Background()))
 	require.Equal(suite.T(), 1, observedLogs.Len())
 	firstLog := observedLogs.All()[0]
 	suite.Equal("Bunt init complete", firstLog.Message)
@@ -1,6 +1,7 @@
 package cassandra
 
 import (
+	"context"
 	"github.com/gocql/gocql"
 	"github.com/justdomepaul/toolbox/config"
 	"go.uber.org/zap"
@@ -67,7 +68,7 @@ var extendOptions = func(cluster *gocql.ClusterConfig, opt config.Cassandra) err
 	return nil
 }
 
-func NewExtendCassandraDatabase(logger *zap.Logger, opt config.Cassandra) (ISession, func(), error) {
+func NewExtendCassandraDatabase(logger *zap.Logger, opt config.Cassandra) (ISession, func(context.Context) error, error) {
 	session, err := NewSession(opt)
 	if err != nil {
 		return nil, nil, err
@@ -75,7 +76,8 @@ func NewExtendCassandraDatabase(logger *zap.Logger, opt config.Cassandra) (ISess
 	logger.Info("CassandraDB init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
+		func(ctx context.Context) error {
 			session.Close()
+			return nil
 		}, nil
 }
@@ -1,6 +1,7 @@
 package cassandra
 
 import (
+	"context"
 	"github.com/cockroachdb/errors"
 	"github.com/gocql/gocql"
 	"github.com/justdomepaul/toolbox/config"
@@ -116,10 +117,8 @@ func (suite *ConnectServiceSuite) TestNewExtendCockroachDatabase() {
 		})
 	suite.NoError(err)
 	suite.Equal("*gocql.Session", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
-	suite.NotPanics(func() {
-		fn()
-	})
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
+	suite.NoError(fn(context.Background()))
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendCockroachDatabaseNewSessionError() {
@@ -4,7 +4,6 @@ import (
 	"cloud.google.com/go/storage"
 	"context"
 	"github.com/justdomepaul/toolbox/config"
-	"github.com/justdomepaul/toolbox/errorhandler"
 	"go.uber.org/zap"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc"
@@ -40,7 +39,7 @@ var NewSession = func(ctx context.Context, opt config.Cloud) (*storage.Client, e
 	return newClient(ctx, options...)
 }
 
-func NewExtendStorageDatabase(logger *zap.Logger, opt config.Cloud) (*storage.Client, func(), error) {
+func NewExtendStorageDatabase(logger *zap.Logger, opt config.Cloud) (*storage.Client, func(context.Context) error, error) {
 	session, err := NewSession(context.Background(), opt)
 	if err != nil {
 		return nil, nil, err
@@ -48,10 +47,7 @@ func NewExtendStorageDatabase(logger *zap.Logger, opt config.Cloud) (*storage.Cl
 	logger.Info("Storage init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
-			if err := session.Close(); err != nil {
-				panic(err)
-			}
+		func(ctx context.Context) error {
+			return session.Close()
 		}, nil
 }
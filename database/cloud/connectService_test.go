@@ -45,9 +45,9 @@ func (suite *ConnectServiceSuite) TestNewExtendStorageDatabase() {
 		zap.NewExample(),
 		option)
 	suite.NoError(err)
-	defer fn()
+	defer fn(context.Background())
 	suite.Equal("*storage.Client", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendStorageDatabaseNewSessionError() {
@@ -88,7 +88,7 @@ var NewSession = func(opt config.Cockroach) (ISession, error) {
 	)
 }
 
-func NewExtendCockroachDatabase(logger *zap.Logger, opt config.Cockroach) (ISession, func(), error) {
+func NewExtendCockroachDatabase(logger *zap.Logger, opt config.Cockroach) (ISession, func(context.Context) error, error) {
 	session, err := NewSession(opt)
 	if err != nil {
 		return nil, nil, err
@@ -96,10 +96,10 @@ func NewExtendCockroachDatabase(logger *zap.Logger, opt config.Cockroach) (ISess
 	logger.Info("CockroachDB init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
+		func(ctx context.Context) error {
 			if err := session.Close(); err != nil {
-				panic(errorhandler.NewErrDBDisconnection(err))
+				return errorhandler.NewErrDBDisconnection(err)
 			}
+			return nil
 		}, nil
 }
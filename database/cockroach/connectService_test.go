@@ -1,6 +1,7 @@
 package cockroach
 
 import (
+	"context"
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
 	"github.com/justdomepaul/toolbox/config"
@@ -56,7 +57,7 @@ func (suite *ConnectServiceSuite) TestNewExtendCockroachDatabase() {
 		config.Cockroach{})
 	suite.NoError(err)
 	suite.Equal("*cockroach.testISession", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendCockroachDatabaseNewSessionError() {
@@ -75,9 +76,7 @@ func (suite *ConnectServiceSuite) TestNewExtendCockroachDatabaseSessionCloseErro
 
 	_, fn, err := NewExtendCockroachDatabase(zap.NewExample(), config.Cockroach{})
 	suite.NoError(err)
-	suite.NotPanics(func() {
-		fn()
-	})
+	suite.Error(fn(context.Background()))
 }
 
 func TestConnectServiceSuite(t *testing.T) {
@@ -34,7 +34,7 @@ var NewSession = func(ctx context.Context, opt config.Firestore) (ISession, erro
 	return newClient(ctx, opt.ProjectID, options...)
 }
 
-func NewExtendFirestoreDatabase(logger *zap.Logger, opt config.Firestore) (ISession, func(), error) {
+func NewExtendFirestoreDatabase(logger *zap.Logger, opt config.Firestore) (ISession, func(context.Context) error, error) {
 	session, err := NewSession(context.Background(), opt)
 	if err != nil {
 		return nil, nil, err
@@ -42,10 +42,10 @@ func NewExtendFirestoreDatabase(logger *zap.Logger, opt config.Firestore) (ISess
 	logger.Info("Firestore init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
+		func(ctx context.Context) error {
 			if err := session.Close(); err != nil {
-				panic(errorhandler.NewErrDBDisconnection(err))
+				return errorhandler.NewErrDBDisconnection(err)
 			}
+			return nil
 		}, nil
 }
@@ -37,7 +37,9 @@ func (suite *ConnectServiceSuite) TestNewSession() {
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendFirestoreDatabase() {
-	defer gostub.StubFunc(&NewSession, new(testISession), nil).Reset()
+	session := new(testISession)
+	session.On("Close").Return(nil)
+	defer gostub.StubFunc(&NewSession, session, nil).Reset()
 
 	option := config.Firestore{}
 	suite.NoError(config.LoadFromEnv(&option))
@@ -45,9 +47,9 @@ func (suite *ConnectServiceSuite) TestNewExtendFirestoreDatabase() {
 		zap.NewExample(),
 		option)
 	suite.NoError(err)
-	defer fn()
+	suite.NoError(fn(context.Background()))
 	suite.Equal("*firestore.testISession", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendFirestoreDatabaseNewSessionError() {
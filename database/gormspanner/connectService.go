@@ -140,7 +140,7 @@ var NewSession = func(ctx context.Context, opt config.Spanner) (*gorm.DB, error)
 	})
 }
 
-func NewExtendGormSpannerDatabase(logger *zap.Logger, opt config.Spanner) (*gorm.DB, func(), error) {
+func NewExtendGormSpannerDatabase(logger *zap.Logger, opt config.Spanner) (*gorm.DB, func(context.Context) error, error) {
 	session, err := NewSession(context.Background(), opt)
 	if err != nil {
 		return nil, nil, err
@@ -148,14 +148,14 @@ func NewExtendGormSpannerDatabase(logger *zap.Logger, opt config.Spanner) (*gorm
 	logger.Info("Gorm Spanner init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
+		func(ctx context.Context) error {
 			sqlDB, err := session.DB()
 			if err != nil {
-				panic(errorhandler.NewErrDBDisconnection(err))
+				return errorhandler.NewErrDBDisconnection(err)
 			}
 			if err := sqlDB.Close(); err != nil {
-				panic(errorhandler.NewErrDBDisconnection(err))
+				return errorhandler.NewErrDBDisconnection(err)
 			}
+			return nil
 		}, nil
 }
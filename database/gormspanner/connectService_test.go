@@ -70,9 +70,9 @@ func (suite *ConnectServiceSuite) TestNewExtendGormSpannerDatabase() {
 		zap.NewExample(),
 		option)
 	suite.NoError(err)
-	defer fn()
+	defer fn(context.Background())
 	suite.Equal("*gorm.DB", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
 }
 
 func TestConnectServiceSuite(t *testing.T) {
@@ -4,7 +4,6 @@ import (
 	"cloud.google.com/go/logging/logadmin"
 	"context"
 	"github.com/justdomepaul/toolbox/config"
-	"github.com/justdomepaul/toolbox/errorhandler"
 	"go.uber.org/zap"
 	"google.golang.org/api/option"
 )
@@ -39,7 +38,7 @@ var NewSession = func(ctx context.Context, opt config.Spanner, opts ...option.Cl
 	return newClient(ctx, opt.ProjectID, opts...)
 }
 
-func NewExtendLoggingAdmin(logger *zap.Logger, opt config.Spanner) (ISession, func(), error) {
+func NewExtendLoggingAdmin(logger *zap.Logger, opt config.Spanner) (ISession, func(context.Context) error, error) {
 	session, err := NewSession(context.Background(), opt)
 	if err != nil {
 		return nil, nil, err
@@ -47,10 +46,7 @@ func NewExtendLoggingAdmin(logger *zap.Logger, opt config.Spanner) (ISession, fu
 	logger.Info("logging admin init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
-			if err := session.Close(); err != nil {
-				panic(err)
-			}
+		func(ctx context.Context) error {
+			return session.Close()
 		}, nil
 }
@@ -37,16 +37,18 @@ func (suite *ConnectServiceSuite) TestNewSession() {
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendLoggingAdmin() {
-	defer gostub.StubFunc(&NewSession, new(testISession), nil).Reset()
+	session := new(testISession)
+	session.On("Close").Return(nil)
+	defer gostub.StubFunc(&NewSession, session, nil).Reset()
 	option := config.Spanner{}
 	suite.NoError(config.LoadFromEnv(&option))
 	result, fn, err := NewExtendLoggingAdmin(
 		zap.NewExample(),
 		option)
 	suite.NoError(err)
-	defer fn()
+	suite.NoError(fn(context.Background()))
 	suite.Equal("*loggingadmin.testISession", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendLoggingAdminNewSessionError() {
@@ -58,19 +58,18 @@ var NewSession = func(ctx context.Context, opt config.Mongo) (ISession, error) {
 	return newClient(ctx, clientOptions)
 }
 
-func NewExtendMongoDatabase(logger *zap.Logger, opt config.Mongo) (ISession, func(), error) {
-	ctx := context.Background()
-	session, err := NewSession(ctx, opt)
+func NewExtendMongoDatabase(logger *zap.Logger, opt config.Mongo) (ISession, func(context.Context) error, error) {
+	session, err := NewSession(context.Background(), opt)
 	if err != nil {
 		return nil, nil, err
 	}
 	logger.Info("MongoDB init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
+		func(ctx context.Context) error {
 			if err := session.Disconnect(ctx); err != nil {
-				panic(errorhandler.NewErrDBDisconnection(err))
+				return errorhandler.NewErrDBDisconnection(err)
 			}
+			return nil
 		}, nil
 }
@@ -23,6 +23,11 @@ func (t *testISession) Close() error {
 	return args.Error(0)
 }
 
+func (t *testISession) Disconnect(ctx context.Context) error {
+	args := t.Called()
+	return args.Error(0)
+}
+
 type ConnectServiceSuite struct {
 	suite.Suite
 }
@@ -52,7 +57,9 @@ func (suite *ConnectServiceSuite) TestNewSessionWithOption() {
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendMongoDatabase() {
-	defer gostub.StubFunc(&NewSession, new(testISession), nil).Reset()
+	session := new(testISession)
+	session.On("Disconnect").Return(nil)
+	defer gostub.StubFunc(&NewSession, session, nil).Reset()
 
 	option := config.Mongo{}
 	suite.NoError(config.LoadFromEnv(&option))
@@ -60,9 +67,9 @@ func (suite *ConnectServiceSuite) TestNewExtendMongoDatabase() {
 		zap.NewExample(),
 		option)
 	suite.NoError(err)
-	defer fn()
+	suite.NoError(fn(context.Background()))
 	suite.Equal("*mongo.testISession", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendMongoDatabaseNewSessionError() {
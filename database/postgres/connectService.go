@@ -72,8 +72,6 @@ type ISession interface {
 
 // NewSession method
 var NewSession = func(opt config.Postgres) (ISession, error) {
-	fmt.Println(opt)
-
 	if opt.PostgresURL != "" {
 		return Connect("pgx",
 			opt.PostgresURL,
@@ -90,7 +88,7 @@ var NewSession = func(opt config.Postgres) (ISession, error) {
 	)
 }
 
-func NewExtendPostgresDatabase(logger *zap.Logger, opt config.Postgres) (ISession, func(), error) {
+func NewExtendPostgresDatabase(logger *zap.Logger, opt config.Postgres) (ISession, func(context.Context) error, error) {
 	session, err := NewSession(opt)
 	if err != nil {
 		return nil, nil, err
@@ -98,10 +96,10 @@ func NewExtendPostgresDatabase(logger *zap.Logger, opt config.Postgres) (ISessio
 	logger.Info("Postgres init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
+		func(ctx context.Context) error {
 			if err := session.Close(); err != nil {
-				panic(errorhandler.NewErrDBDisconnection(err))
+				return errorhandler.NewErrDBDisconnection(err)
 			}
+			return nil
 		}, nil
 }
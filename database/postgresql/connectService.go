@@ -88,7 +88,7 @@ var NewSession = func(opt config.Postgresql) (ISession, error) {
 	)
 }
 
-func NewExtendPostgresqlDatabase(logger *zap.Logger, opt config.Postgresql) (ISession, func(), error) {
+func NewExtendPostgresqlDatabase(logger *zap.Logger, opt config.Postgresql) (ISession, func(context.Context) error, error) {
 	session, err := NewSession(opt)
 	if err != nil {
 		return nil, nil, err
@@ -96,10 +96,10 @@ func NewExtendPostgresqlDatabase(logger *zap.Logger, opt config.Postgresql) (ISe
 	logger.Info("Postgresql init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
+		func(ctx context.Context) error {
 			if err := session.Close(); err != nil {
-				panic(errorhandler.NewErrDBDisconnection(err))
+				return errorhandler.NewErrDBDisconnection(err)
 			}
+			return nil
 		}, nil
 }
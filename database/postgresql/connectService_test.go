@@ -1,6 +1,7 @@
 package postgresql
 
 import (
+	"context"
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
 	"github.com/justdomepaul/toolbox/config"
@@ -56,7 +57,7 @@ func (suite *ConnectServiceSuite) TestNewExtendPostgresqlDatabase() {
 		config.Postgresql{})
 	suite.NoError(err)
 	suite.Equal("*postgresql.testISession", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendPostgresqlDatabaseNewSessionError() {
@@ -75,9 +76,7 @@ func (suite *ConnectServiceSuite) TestNewExtendPostgresqlDatabaseSessionCloseErr
 
 	_, fn, err := NewExtendPostgresqlDatabase(zap.NewExample(), config.Postgresql{})
 	suite.NoError(err)
-	suite.NotPanics(func() {
-		fn()
-	})
+	suite.Error(fn(context.Background()))
 }
 
 func TestConnectServiceSuite(t *testing.T) {
@@ -47,7 +47,7 @@ var NewSession = func(ctx context.Context, opt config.PubSub) (ISession, error)
 	return newClient(ctx, fmt.Sprintf(`projects/%s`, opt.ProjectID), options...)
 }
 
-func NewExtendPubSubDatabase(logger *zap.Logger, opt config.PubSub) (ISession, func(), error) {
+func NewExtendPubSubDatabase(logger *zap.Logger, opt config.PubSub) (ISession, func(context.Context) error, error) {
 	session, err := NewSession(context.Background(), opt)
 	if err != nil {
 		return nil, nil, err
@@ -55,8 +55,10 @@ func NewExtendPubSubDatabase(logger *zap.Logger, opt config.PubSub) (ISession, f
 	logger.Info("PubSub init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
-			session.Close()
+		func(ctx context.Context) error {
+			if err := session.Close(); err != nil {
+				return errorhandler.NewErrDBDisconnection(err)
+			}
+			return nil
 		}, nil
 }
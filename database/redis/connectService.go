@@ -52,7 +52,7 @@ var NewSession = func(opt config.Redis) (ISession, error) {
 	return redis.NewClient(rdsOpt), nil
 }
 
-func NewExtendRedisDatabase(logger *zap.Logger, opt config.Redis) (ISession, func(), error) {
+func NewExtendRedisDatabase(logger *zap.Logger, opt config.Redis) (ISession, func(context.Context) error, error) {
 	session, err := NewSession(opt)
 	if err != nil {
 		return nil, nil, err
@@ -60,10 +60,10 @@ func NewExtendRedisDatabase(logger *zap.Logger, opt config.Redis) (ISession, fun
 	logger.Info("Redis init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
+		func(ctx context.Context) error {
 			if err := session.Close(); err != nil {
-				panic(errorhandler.NewErrDBDisconnection(err))
+				return errorhandler.NewErrDBDisconnection(err)
 			}
+			return nil
 		}, nil
 }
@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"github.com/cockroachdb/errors"
 	"github.com/justdomepaul/toolbox/config"
 	"github.com/prashantv/gostub"
@@ -36,10 +37,8 @@ func (suite *ConnectServiceSuite) TestNewExtendRedisDatabase() {
 		})
 	suite.NoError(err)
 	suite.Equal("*redis.Client", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
-	suite.NotPanics(func() {
-		fn()
-	})
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
+	suite.NoError(fn(context.Background()))
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendRedisDatabaseNewSessionError() {
@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/justdomepaul/toolbox/config"
-	"github.com/justdomepaul/toolbox/errorhandler"
 	"go.uber.org/zap"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc"
@@ -45,7 +44,7 @@ var NewSession = func(ctx context.Context, opt config.Spanner) (ISession, error)
 	return newClient(ctx, fmt.Sprintf(`projects/%s/instances/%s/databases/%s`, opt.ProjectID, opt.Instance, opt.Database), options...)
 }
 
-func NewExtendSpannerDatabase(logger *zap.Logger, opt config.Spanner) (ISession, func(), error) {
+func NewExtendSpannerDatabase(logger *zap.Logger, opt config.Spanner) (ISession, func(context.Context) error, error) {
 	session, err := NewSession(context.Background(), opt)
 	if err != nil {
 		return nil, nil, err
@@ -53,8 +52,8 @@ func NewExtendSpannerDatabase(logger *zap.Logger, opt config.Spanner) (ISession,
 	logger.Info("Spanner init complete", zap.String("system", "Database"))
 
 	return session,
-		func() {
-			defer errorhandler.PanicErrorHandler("Core", "")
+		func(ctx context.Context) error {
 			session.Close()
+			return nil
 		}, nil
 }
@@ -38,16 +38,18 @@ func (suite *ConnectServiceSuite) TestNewSession() {
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendSpannerDatabase() {
-	defer gostub.StubFunc(&NewSession, new(testISession), nil).Reset()
+	session := new(testISession)
+	session.On("Close")
+	defer gostub.StubFunc(&NewSession, session, nil).Reset()
 	option := config.Spanner{}
 	suite.NoError(config.LoadFromEnv(&option))
 	result, fn, err := NewExtendSpannerDatabase(
 		zap.NewExample(),
 		option)
 	suite.NoError(err)
-	defer fn()
+	suite.NoError(fn(context.Background()))
 	suite.Equal("*spanner.testISession", reflect.TypeOf(result).String())
-	suite.Equal("func()", reflect.TypeOf(fn).String())
+	suite.Equal("func(context.Context) error", reflect.TypeOf(fn).String())
 }
 
 func (suite *ConnectServiceSuite) TestNewExtendSpannerDatabaseNewSessionError() {
@@ -0,0 +1,5 @@
+package definition
+
+const (
+	RequestIDKey = "x-request-id"
+)
@@ -0,0 +1,18 @@
+package definition
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type RequestSuite struct {
+	suite.Suite
+}
+
+func (suite *RequestSuite) TestGetConstant() {
+	suite.Equal("x-request-id", RequestIDKey)
+}
+
+func TestRequestSuite(t *testing.T) {
+	suite.Run(t, new(RequestSuite))
+}
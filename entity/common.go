@@ -1,7 +1,9 @@
 package entity
 
 type CommonListResponse struct {
-	Count int64 `spanner:"Count" json:"Count,omitempty"`
-	Row   int64 `spanner:"Row" json:"Row,omitempty"`
-	Page  int64 `spanner:"Page" json:"Page,omitempty"`
+	Count      int64  `spanner:"Count" json:"Count,omitempty"`
+	Row        int64  `spanner:"Row" json:"Row,omitempty"`
+	Page       int64  `spanner:"Page" json:"Page,omitempty"`
+	NextCursor string `spanner:"-" json:"NextCursor,omitempty"`
+	PrevCursor string `spanner:"-" json:"PrevCursor,omitempty"`
 }
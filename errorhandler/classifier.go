@@ -0,0 +1,145 @@
+package errorhandler
+
+import (
+	"cloud.google.com/go/storage"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/tidwall/buntdb"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Classifier type
+// maps driver error to Kind, false when error is unknown to classifier
+type Classifier func(err error) (Kind, bool)
+
+var (
+	classifiers      = defaultClassifiers()
+	classifiersMutex sync.RWMutex
+)
+
+// RegisterClassifier method
+// classifier registered later takes precedence, e.g. to override pre-populated driver classifiers
+func RegisterClassifier(classifier Classifier) {
+	classifiersMutex.Lock()
+	defer classifiersMutex.Unlock()
+	classifiers = append([]Classifier{classifier}, classifiers...)
+}
+
+// Classify method
+// kind of driver error by registered classifiers, false when no classifier recognises err
+func Classify(err error) (Kind, bool) {
+	if err == nil {
+		return Kind{}, false
+	}
+	classifiersMutex.RLock()
+	defer classifiersMutex.RUnlock()
+	for _, classifier := range classifiers {
+		if kind, ok := classifier(err); ok {
+			return kind, true
+		}
+	}
+	return Kind{}, false
+}
+
+// ClassifyAs method
+// classifier of errors matching any of targets by errors.Is
+func ClassifyAs(kind Kind, targets ...error) Classifier {
+	return func(err error) (Kind, bool) {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return kind, true
+			}
+		}
+		return Kind{}, false
+	}
+}
+
+func defaultClassifiers() []Classifier {
+	return []Classifier{
+		ClassifyAs(KindDBRowNotFound,
+			ErrNoRows, sql.ErrNoRows, iterator.Done,
+			pgx.ErrNoRows, gocql.ErrNotFound, buntdb.ErrNotFound, redis.Nil, mongo.ErrNoDocuments, gorm.ErrRecordNotFound,
+			storage.ErrObjectNotExist, storage.ErrBucketNotExist,
+		),
+		ClassifyAs(KindDBAlreadyExists, ErrAlreadyExists, gorm.ErrDuplicatedKey),
+		ClassifyAs(KindDBConnection,
+			driver.ErrBadConn, sql.ErrConnDone, gocql.ErrNoConnections, gocql.ErrConnectionClosed, redis.ErrClosed,
+		),
+		ClassifyAs(KindDBAborted, sql.ErrTxDone, redis.TxFailedErr),
+		ClassifyAs(KindDBTimeout, context.DeadlineExceeded, os.ErrDeadlineExceeded, gocql.ErrTimeoutNoResponse),
+		ClassifyAs(KindDBCanceled, context.Canceled),
+		classifyPostgres,
+		classifyMongo,
+		classifyGRPC,
+	}
+}
+
+// classifyPostgres classifies pgx error by SQLSTATE, also used by CockroachDB
+func classifyPostgres(err error) (Kind, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		if pgconn.Timeout(err) {
+			return KindDBTimeout, true
+		}
+		return Kind{}, false
+	}
+	switch {
+	case pgErr.Code == "23505": // unique_violation
+		return KindDBAlreadyExists, true
+	case pgErr.Code == "40001", pgErr.Code == "40P01": // serialization_failure, deadlock_detected
+		return KindDBAborted, true
+	case pgErr.Code == "57014": // query_canceled
+		return KindDBCanceled, true
+	case strings.HasPrefix(pgErr.Code, "08"): // connection_exception
+		return KindDBConnection, true
+	}
+	return Kind{}, false
+}
+
+func classifyMongo(err error) (Kind, bool) {
+	switch {
+	case mongo.IsDuplicateKeyError(err):
+		return KindDBAlreadyExists, true
+	case mongo.IsTimeout(err):
+		return KindDBTimeout, true
+	case mongo.IsNetworkError(err):
+		return KindDBConnection, true
+	}
+	return Kind{}, false
+}
+
+// classifyGRPC classifies status error of gRPC based driver, e.g. Spanner, Firestore and Pub/Sub
+func classifyGRPC(err error) (Kind, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return Kind{}, false
+	}
+	switch st.Code() {
+	case codes.NotFound:
+		return KindDBRowNotFound, true
+	case codes.AlreadyExists:
+		return KindDBAlreadyExists, true
+	case codes.Aborted:
+		return KindDBAborted, true
+	case codes.DeadlineExceeded:
+		return KindDBTimeout, true
+	case codes.Canceled:
+		return KindDBCanceled, true
+	case codes.Unavailable:
+		return KindDBConnection, true
+	}
+	return Kind{}, false
+}
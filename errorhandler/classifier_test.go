@@ -0,0 +1,129 @@
+package errorhandler
+
+import (
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/storage"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gocql/gocql"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"github.com/tidwall/buntdb"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ClassifierSuite struct {
+	suite.Suite
+}
+
+func (suite *ClassifierSuite) SetupTest() {
+	observedZapCore, _ := observer.New(zap.WarnLevel)
+	logger = zap.New(observedZapCore)
+}
+
+func (suite *ClassifierSuite) TestClassify() {
+	for kind, errs := range map[string][]error{
+		ErrDbRowNotFound: {
+			ErrNoRows, sql.ErrNoRows, iterator.Done, pgx.ErrNoRows, gocql.ErrNotFound, buntdb.ErrNotFound, redis.Nil,
+			mongo.ErrNoDocuments, gorm.ErrRecordNotFound, storage.ErrObjectNotExist, fmt.Errorf("select: %w", sql.ErrNoRows),
+			spanner.ToSpannerError(status.Error(codes.NotFound, "row not found")),
+		},
+		ErrDbAlreadyExists: {
+			ErrAlreadyExists, gorm.ErrDuplicatedKey, &pgconn.PgError{Code: "23505"},
+			mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}},
+			status.Error(codes.AlreadyExists, "document exists"),
+		},
+		ErrDbConnection: {
+			driver.ErrBadConn, gocql.ErrNoConnections, redis.ErrClosed, &pgconn.PgError{Code: "08006"},
+			status.Error(codes.Unavailable, "unavailable"),
+		},
+		ErrDbAborted: {
+			&pgconn.PgError{Code: "40001"}, fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40P01"}), redis.TxFailedErr,
+			spanner.ToSpannerError(status.Error(codes.Aborted, "transaction aborted")),
+		},
+		ErrDbTimeout: {
+			context.DeadlineExceeded, gocql.ErrTimeoutNoResponse, status.Error(codes.DeadlineExceeded, "deadline"),
+		},
+		ErrDbCanceled: {
+			context.Canceled, &pgconn.PgError{Code: "57014"}, status.Error(codes.Canceled, "canceled"),
+		},
+	} {
+		for _, err := range errs {
+			result, ok := Classify(err)
+			suite.True(ok, err.Error())
+			suite.Equal(kind, result.Name, err.Error())
+		}
+	}
+
+	for _, err := range []error{
+		nil, errors.New("got error"), ErrUpdateNoEffect, &pgconn.PgError{Code: "23503"},
+		status.Error(codes.Internal, "internal"), mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 1}}},
+	} {
+		_, ok := Classify(err)
+		suite.False(ok)
+	}
+}
+
+func (suite *ClassifierSuite) TestRegisterClassifier() {
+	errQuota := errors.New("quota exceeded")
+	kind := Kind{Name: "errQuotaExceeded", HTTPStatus: http.StatusTooManyRequests, GRPCCode: codes.ResourceExhausted}
+	defer func(origin []Classifier) {
+		classifiers = origin
+	}(classifiers)
+	RegisterClassifier(ClassifyAs(kind, errQuota, sql.ErrNoRows))
+
+	result, ok := Classify(fmt.Errorf("insert: %w", errQuota))
+	suite.True(ok)
+	suite.Equal(kind, result)
+	result, ok = Classify(sql.ErrNoRows)
+	suite.True(ok)
+	suite.Equal(kind, result)
+}
+
+func (suite *ClassifierSuite) TestErrDBExecute() {
+	gin.SetMode(gin.ReleaseMode)
+	for input, code := range map[error]int{
+		&pgconn.PgError{Code: "23505"}:            http.StatusConflict,
+		fmt.Errorf("get: %w", redis.Nil):          http.StatusNotFound,
+		fmt.Errorf("query: %w", context.Canceled): 499,
+		errors.New("got error"):                   http.StatusConflict,
+	} {
+		route := gin.New()
+		route.Use(GinPanicErrorHandler("Mock Gin", "error Gin mock"))
+		route.GET("/", func(c *gin.Context) {
+			panic(NewErrDBExecute(input))
+		})
+		w := httptest.NewRecorder()
+		route.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		suite.Equal(code, w.Code, input.Error())
+	}
+
+	suite.Equal(KindDBTimeout, NewErrDBExecute(context.DeadlineExceeded).Classify())
+	suite.Equal(KindDBExecute, NewErrDBExecute(errors.New("got error")).Classify())
+
+	var errContent error
+	NewErrDBExecute(&pgconn.PgError{Code: "40001"}).SetSystem("Mock system").(IGRPCErrorReport).GRPCReport(&errContent, "prefix")
+	suite.Equal(codes.Aborted, status.Code(errContent))
+	report, ok := FromGRPCError(errContent)
+	suite.True(ok)
+	suite.IsType(&ErrDBExecute{}, report)
+}
+
+func TestClassifierSuite(t *testing.T) {
+	suite.Run(t, new(ClassifierSuite))
+}
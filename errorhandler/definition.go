@@ -13,6 +13,9 @@ const (
 	ErrDbExecute              = "errDBExecute"
 	ErrDbRowNotFound          = "errDBRowNotFound"
 	ErrDbUpdateNoEffect       = "errDBUpdateNoEffect"
+	ErrDbAborted              = "errDBAborted"
+	ErrDbTimeout              = "errDBTimeout"
+	ErrDbCanceled             = "errDBCanceled"
 	ErrProcessExecute         = "errExecute"
 	ErrGrpcConnection         = "errGRPCConnection"
 	ErrGrpcExecute            = "errGRPCExecute"
@@ -42,7 +42,7 @@ func (suite *ErrAuthenticateSuite) TestNewErrAuthenticateImplementError() {
 }
 
 func (suite *ErrAuthenticateSuite) TestNewErrAuthenticateErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrAuthenticate(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrAuthenticate(errors.New("got error")).Error())
 }
 
 func (suite *ErrAuthenticateSuite) TestNewErrAuthenticateReportMethod() {
@@ -42,7 +42,7 @@ func (suite *ErrDBAlreadyExistsSuite) TestNewErrDBAlreadyExistsImplementError()
 }
 
 func (suite *ErrDBAlreadyExistsSuite) TestNewErrDBAlreadyExistsErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrDBAlreadyExists(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrDBAlreadyExists(errors.New("got error")).Error())
 }
 
 func (suite *ErrDBAlreadyExistsSuite) TestNewErrDBAlreadyExistsReportMethod() {
@@ -42,7 +42,7 @@ func (suite *ErrDBConnectionSuite) TestNewErrDBConnectionImplementError() {
 }
 
 func (suite *ErrDBConnectionSuite) TestNewErrDBConnectionErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrDBConnection(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrDBConnection(errors.New("got error")).Error())
 }
 
 func (suite *ErrDBConnectionSuite) TestNewErrDBConnectionReportMethod() {
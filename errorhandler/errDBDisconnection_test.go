@@ -42,7 +42,7 @@ func (suite *ErrDBDisconnectionSuite) TestNewErrDBDisconnectionImplementError()
 }
 
 func (suite *ErrDBDisconnectionSuite) TestNewErrDBDisconnectionErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrDBDisconnection(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrDBDisconnection(errors.New("got error")).Error())
 }
 
 func (suite *ErrDBDisconnectionSuite) TestNewErrDBDisconnectionReportMethod() {
@@ -1,12 +1,16 @@
 package errorhandler
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"net/http"
 	"net/http/httptest"
@@ -42,7 +46,7 @@ func (suite *ErrDBExecuteSuite) TestNewErrDBExecuteImplementError() {
 }
 
 func (suite *ErrDBExecuteSuite) TestNewErrDBExecuteErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrDBExecute(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrDBExecute(errors.New("got error")).Error())
 }
 
 func (suite *ErrDBExecuteSuite) TestNewErrDBExecuteReportMethod() {
@@ -89,6 +93,31 @@ func (suite *ErrDBExecuteSuite) TestPanicGRPCErrorHandlerNewErrDBExecute() {
 	}
 }
 
+func (suite *ErrDBExecuteSuite) TestNewErrDBExecuteWrappedError() {
+	gin.SetMode(gin.ReleaseMode)
+	for input, code := range map[error]int{
+		fmt.Errorf("select user: %w", sql.ErrNoRows):  http.StatusNotFound,
+		errors.Wrap(driver.ErrBadConn, "select user"): http.StatusServiceUnavailable,
+	} {
+		route := gin.New()
+		route.Use(GinPanicErrorHandler("Mock Gin", "error Gin mock"))
+		route.GET("/", func(c *gin.Context) {
+			panic(NewErrDBExecute(input))
+		})
+		w := httptest.NewRecorder()
+		route.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		suite.Equal(code, w.Code)
+	}
+
+	var errContent error
+	NewErrDBExecute(fmt.Errorf("select user: %w", ErrNoRows)).GRPCReport(&errContent, "prefix")
+	suite.Equal(codes.NotFound, status.Code(errContent))
+	NewErrDBExecute(fmt.Errorf("update user: %w", ErrUpdateNoEffect)).GRPCReport(&errContent, "prefix")
+	suite.Equal(codes.FailedPrecondition, status.Code(errContent))
+	NewErrDBExecute(errors.Wrap(driver.ErrBadConn, "select user")).GRPCReport(&errContent, "prefix")
+	suite.Equal(codes.Unavailable, status.Code(errContent))
+}
+
 func TestErrDBExecuteSuite(t *testing.T) {
 	suite.Run(t, new(ErrDBExecuteSuite))
 }
@@ -42,7 +42,7 @@ func (suite *ErrDBRowNotFoundSuite) TestNewErrDBRowNotFoundImplementError() {
 }
 
 func (suite *ErrDBRowNotFoundSuite) TestNewErrDBRowNotFoundErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrDBRowNotFound(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrDBRowNotFound(errors.New("got error")).Error())
 }
 
 func (suite *ErrDBRowNotFoundSuite) TestNewErrDBRowNotFoundReportMethod() {
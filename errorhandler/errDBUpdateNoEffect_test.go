@@ -42,7 +42,7 @@ func (suite *ErrDBUpdateNoEffectSuite) TestNewErrDBUpdateNoEffectImplementError(
 }
 
 func (suite *ErrDBUpdateNoEffectSuite) TestNewErrDBUpdateNoEffectErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrDBUpdateNoEffect(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrDBUpdateNoEffect(errors.New("got error")).Error())
 }
 
 func (suite *ErrDBUpdateNoEffectSuite) TestNewErrDBUpdateNoEffectReportMethod() {
@@ -42,7 +42,7 @@ func (suite *ErrExecuteSuite) TestNewErrExecuteImplementError() {
 }
 
 func (suite *ErrExecuteSuite) TestNewErrExecuteErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrExecute(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrExecute(errors.New("got error")).Error())
 }
 
 func (suite *ErrExecuteSuite) TestNewErrExecuteReportMethod() {
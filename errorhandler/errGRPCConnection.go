@@ -5,52 +5,38 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"net/http"
 )
 
 type ErrGRPCConnection struct {
-	system   string
-	err      error
+	KindError
 	response interface{}
 }
 
 func (e *ErrGRPCConnection) SetSystem(system string) IErrorReport {
-	if e.system == "" {
-		e.system = system
-	}
+	e.KindError.SetSystem(system)
 	return e
 }
 
-// GetName method
-func (e ErrGRPCConnection) GetName() string {
-	return ErrGrpcConnection
-}
-
-func (e ErrGRPCConnection) GetError() error {
-	return e.err
-}
-
 func (e ErrGRPCConnection) Error() string {
-	return fmt.Sprintln("[ERROR]:", e.err.Error(), ", response data:", e.response)
+	return fmt.Sprintf("[ERROR]: %s, response data: %v", e.err.Error(), e.response)
 }
 
 func (e ErrGRPCConnection) Report(prefix string) {
-	logger.Warn(prefix, zap.Error(e.GetError()), zap.Error(fmt.Errorf("response data: %v \n", e.response)))
+	// response as structured field, sensitive keys are masked only when masking logger set by SetLogger
+	logger.Warn(prefix, zap.Error(e.GetError()), zap.Any("response", e.response), e.stackTraceField())
 }
 
 func (e ErrGRPCConnection) GinReport(c *gin.Context) {
-	c.AbortWithStatusJSON(http.StatusServiceUnavailable, e.response)
+	c.AbortWithStatusJSON(e.httpStatus(), e.response)
 }
 
 func (e ErrGRPCConnection) GRPCReport(errContent *error, prefixMessage string) {
-	*errContent = status.Error(codes.Unavailable, errors.Wrap(fmt.Errorf("response data: %v", e.response), errors.Wrap(e.err, prefixMessage).Error()).Error())
+	*errContent = e.grpcStatus(e.grpcCode(), errors.Wrap(fmt.Errorf("response data: %v", e.response), errors.Wrap(e.err, prefixMessage).Error()).Error())
 }
 
 func NewErrGRPCConnection(err error, response interface{}) *ErrGRPCConnection {
 	return &ErrGRPCConnection{
-		err:      err,
-		response: response,
+		KindError: newKindError(KindGRPCConnection, err),
+		response:  response,
 	}
 }
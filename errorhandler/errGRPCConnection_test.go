@@ -3,6 +3,7 @@ package errorhandler
 import (
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
+	zapTool "github.com/justdomepaul/toolbox/zap"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
@@ -54,7 +55,7 @@ func (suite *ErrGRPCConnectionSuite) TestNewErrGRPCConnectionImplementError() {
 }
 
 func (suite *ErrGRPCConnectionSuite) TestNewErrGRPCConnectionErrorMethod() {
-	suite.Equal("[ERROR]: got error , response data: {}\n", NewErrGRPCConnection(
+	suite.Equal("[ERROR]: got error, response data: {}", NewErrGRPCConnection(
 		errors.New("got error"),
 		struct{}{},
 	).Error())
@@ -67,6 +68,23 @@ func (suite *ErrGRPCConnectionSuite) TestNewErrGRPCConnectionReportMethod() {
 	suite.Equal("Error GRPC", firstLog.Message)
 	suite.Equal("Mock system", firstLog.Context[0].String)
 	suite.Equal("got error", errors.Cause(firstLog.Context[1].Interface.(error)).Error())
+	suite.Equal("response", firstLog.Context[2].Key)
+}
+
+func (suite *ErrGRPCConnectionSuite) TestNewErrGRPCConnectionReportMasking() {
+	observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+	SetLogger(zap.New(zapTool.NewMasker(zapTool.DefaultMaskKeys, zapTool.MaskPatterns...).Core(observedZapCore)))
+	NewErrGRPCConnection(errors.New("got error"), map[string]interface{}{
+		"authorization": "Bearer value",
+		"token":         "value",
+		"code":          "denied",
+	}).Report("Error GRPC")
+	require.Equal(suite.T(), 1, observedLogs.Len())
+	suite.Equal(map[string]interface{}{
+		"authorization": zapTool.Mask,
+		"token":         zapTool.Mask,
+		"code":          "denied",
+	}, observedLogs.All()[0].ContextMap()["response"])
 }
 
 func (suite *ErrGRPCConnectionSuite) TestNewErrGRPCConnectionGinReportMethod() {
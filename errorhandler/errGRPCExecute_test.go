@@ -42,7 +42,7 @@ func (suite *ErrGRPCExecuteSuite) TestNewErrGRPCExecuteImplementError() {
 }
 
 func (suite *ErrGRPCExecuteSuite) TestNewErrGRPCExecuteErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrGRPCExecute(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrGRPCExecute(errors.New("got error")).Error())
 }
 
 func (suite *ErrGRPCExecuteSuite) TestNewErrGRPCExecuteReportMethod() {
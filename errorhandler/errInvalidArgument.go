@@ -1,54 +1,55 @@
 package errorhandler
 
 import (
-	"fmt"
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"net/http"
 )
 
+// FieldViolation type
+// describes a single invalid request field, shaped like google.rpc.BadRequest.FieldViolation
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
 type ErrInvalidArgument struct {
-	system string
-	err    error
+	KindError
+	violations []FieldViolation
 }
 
 func (e *ErrInvalidArgument) SetSystem(system string) IErrorReport {
-	if e.system == "" {
-		e.system = system
-	}
+	e.KindError.SetSystem(system)
 	return e
 }
 
-// GetName method
-func (e ErrInvalidArgument) GetName() string {
-	return ErrProcessInvalidArgument
-}
-
-func (e ErrInvalidArgument) GetError() error {
-	return e.err
-}
-
-func (e ErrInvalidArgument) Error() string {
-	return fmt.Sprintln("[ERROR]:", e.err.Error())
-}
-
-func (e ErrInvalidArgument) Report(prefix string) {
-	logger.Warn(prefix, zap.Error(e.GetError()))
+// GetFieldViolations method
+func (e ErrInvalidArgument) GetFieldViolations() []FieldViolation {
+	return e.violations
 }
 
 func (e ErrInvalidArgument) GinReport(c *gin.Context) {
-	c.AbortWithError(http.StatusBadRequest, e.err)
+	if len(e.violations) == 0 {
+		e.KindError.GinReport(c)
+		return
+	}
+	_ = c.Error(e.err)
+	c.AbortWithStatusJSON(e.httpStatus(), gin.H{
+		"message":    e.err.Error(),
+		"violations": e.violations,
+	})
 }
 
 func (e ErrInvalidArgument) GRPCReport(errContent *error, prefixMessage string) {
-	*errContent = status.Error(codes.InvalidArgument, errors.Wrap(e.err, prefixMessage).Error())
+	if len(e.violations) == 0 {
+		e.KindError.GRPCReport(errContent, prefixMessage)
+		return
+	}
+	*errContent = e.grpcStatus(e.grpcCode(), errors.Wrap(e.err, prefixMessage).Error(), badRequest(e.violations))
 }
 
-func NewErrInvalidArgument(err error) *ErrInvalidArgument {
+func NewErrInvalidArgument(err error, violations ...FieldViolation) *ErrInvalidArgument {
 	return &ErrInvalidArgument{
-		err: err,
+		KindError:  newKindError(KindInvalidArgument, err),
+		violations: violations,
 	}
 }
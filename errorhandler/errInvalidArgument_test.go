@@ -42,7 +42,7 @@ func (suite *ErrInvalidArgumentSuite) TestNewErrInvalidArgumentImplementError()
 }
 
 func (suite *ErrInvalidArgumentSuite) TestNewErrInvalidArgumentErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrInvalidArgument(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrInvalidArgument(errors.New("got error")).Error())
 }
 
 func (suite *ErrInvalidArgumentSuite) TestNewErrInvalidArgumentReportMethod() {
@@ -75,6 +75,28 @@ func (suite *ErrInvalidArgumentSuite) TestNewErrInvalidArgumentGinReportMethod()
 	suite.Equal("got error", errors.Cause(firstLog.Context[1].Interface.(error)).Error())
 }
 
+func (suite *ErrInvalidArgumentSuite) TestNewErrInvalidArgumentGetFieldViolationsMethod() {
+	violation := FieldViolation{Field: "name", Description: "failed on the 'required' tag"}
+	suite.Empty(NewErrInvalidArgument(errors.New("got error")).GetFieldViolations())
+	suite.Equal([]FieldViolation{violation}, NewErrInvalidArgument(errors.New("got error"), violation).GetFieldViolations())
+}
+
+func (suite *ErrInvalidArgumentSuite) TestNewErrInvalidArgumentGinReportMethodWithFieldViolations() {
+	gin.SetMode(gin.ReleaseMode)
+	route := gin.New()
+	route.Use(gin.Logger(), GinPanicErrorHandler("Mock Gin", "error Gin mock"))
+	route.GET("/", func(c *gin.Context) {
+		panic(NewErrInvalidArgument(errors.New("got error"), FieldViolation{Field: "name", Description: "failed on the 'required' tag"}))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	route.ServeHTTP(w, req)
+	result := w.Result()
+	defer result.Body.Close()
+	suite.Equal(http.StatusBadRequest, result.StatusCode)
+	suite.JSONEq(`{"message":"got error","violations":[{"field":"name","description":"failed on the 'required' tag"}]}`, w.Body.String())
+}
+
 func (suite *ErrInvalidArgumentSuite) TestPanicGRPCErrorHandlerNewErrInvalidArgument() {
 	var errContent error
 	func() {
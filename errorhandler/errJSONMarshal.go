@@ -1,54 +1,16 @@
 package errorhandler
 
-import (
-	"fmt"
-	"github.com/cockroachdb/errors"
-	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"net/http"
-)
-
 type ErrJSONMarshal struct {
-	system string
-	err    error
+	KindError
 }
 
 func (e *ErrJSONMarshal) SetSystem(system string) IErrorReport {
-	if e.system == "" {
-		e.system = system
-	}
+	e.KindError.SetSystem(system)
 	return e
 }
 
-// GetName method
-func (e ErrJSONMarshal) GetName() string {
-	return ErrJsonMarshal
-}
-
-func (e ErrJSONMarshal) GetError() error {
-	return e.err
-}
-
-func (e ErrJSONMarshal) Error() string {
-	return fmt.Sprintln("[ERROR]:", e.err.Error())
-}
-
-func (e ErrJSONMarshal) Report(prefix string) {
-	logger.Warn(prefix, zap.Error(e.GetError()))
-}
-
-func (e ErrJSONMarshal) GinReport(c *gin.Context) {
-	c.AbortWithError(http.StatusInternalServerError, e.err)
-}
-
-func (e ErrJSONMarshal) GRPCReport(errContent *error, prefixMessage string) {
-	*errContent = status.Error(codes.InvalidArgument, errors.Wrap(e.err, prefixMessage).Error())
-}
-
 func NewErrJSONMarshal(err error) *ErrJSONMarshal {
 	return &ErrJSONMarshal{
-		err: err,
+		KindError: newKindError(KindJSONMarshal, err),
 	}
 }
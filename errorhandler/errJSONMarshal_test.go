@@ -42,7 +42,7 @@ func (suite *ErrJSONMarshalSuite) TestNewErrJSONMarshalImplementError() {
 }
 
 func (suite *ErrJSONMarshalSuite) TestNewErrJSONMarshalErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrJSONMarshal(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrJSONMarshal(errors.New("got error")).Error())
 }
 
 func (suite *ErrJSONMarshalSuite) TestNewErrJSONMarshalReportMethod() {
@@ -42,7 +42,7 @@ func (suite *ErrJSONUnmarshalSuite) TestNewErrJSONUnmarshalImplementError() {
 }
 
 func (suite *ErrJSONUnmarshalSuite) TestNewErrJSONUnmarshalErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrJSONUnmarshal(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrJSONUnmarshal(errors.New("got error")).Error())
 }
 
 func (suite *ErrJSONUnmarshalSuite) TestNewErrJSONUnmarshalReportMethod() {
@@ -42,7 +42,7 @@ func (suite *ErrJWTExecuteSuite) TestNewErrJWTExecuteImplementError() {
 }
 
 func (suite *ErrJWTExecuteSuite) TestNewErrJWTExecuteErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrJWTExecute(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrJWTExecute(errors.New("got error")).Error())
 }
 
 func (suite *ErrJWTExecuteSuite) TestNewErrJWTExecuteReportMethod() {
@@ -42,7 +42,7 @@ func (suite *ErrNotFoundSuite) TestNewErrNotFoundImplementError() {
 }
 
 func (suite *ErrNotFoundSuite) TestNewErrNotFoundErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrNotFound(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrNotFound(errors.New("got error")).Error())
 }
 
 func (suite *ErrNotFoundSuite) TestNewErrNotFoundReportMethod() {
@@ -42,7 +42,7 @@ func (suite *ErrPermissionDenySuite) TestNewErrPermissionDenyImplementError() {
 }
 
 func (suite *ErrPermissionDenySuite) TestNewErrPermissionDenyErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrPermissionDeny(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrPermissionDeny(errors.New("got error")).Error())
 }
 
 func (suite *ErrPermissionDenySuite) TestNewErrPermissionDenyReportMethod() {
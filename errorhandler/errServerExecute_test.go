@@ -42,7 +42,7 @@ func (suite *ErrServerExecuteSuite) TestNewErrServerExecuteImplementError() {
 }
 
 func (suite *ErrServerExecuteSuite) TestNewErrServerExecuteErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrServerExecute(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrServerExecute(errors.New("got error")).Error())
 }
 
 func (suite *ErrServerExecuteSuite) TestNewErrServerExecuteReportMethod() {
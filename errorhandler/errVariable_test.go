@@ -42,7 +42,7 @@ func (suite *ErrVariableSuite) TestNewErrVariableImplementError() {
 }
 
 func (suite *ErrVariableSuite) TestNewErrVariableErrorMethod() {
-	suite.Equal("[ERROR]: got error\n", NewErrVariable(errors.New("got error")).Error())
+	suite.Equal("[ERROR]: got error", NewErrVariable(errors.New("got error")).Error())
 }
 
 func (suite *ErrVariableSuite) TestNewErrVariableReportMethod() {
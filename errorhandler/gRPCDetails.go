@@ -0,0 +1,174 @@
+package errorhandler
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"net/http"
+	"time"
+)
+
+var (
+	// DefaultRetryDelay suggested by RetryInfo of Unavailable status
+	DefaultRetryDelay = time.Second
+)
+
+// WithRetryDelay method
+// RetryInfo delay of Unavailable status, default DefaultRetryDelay
+func WithRetryDelay(delay time.Duration) Option {
+	return withRetryDelay{delay: delay}
+}
+
+type withRetryDelay struct {
+	delay time.Duration
+}
+
+// Apply method
+func (w withRetryDelay) Apply(e *KindError) {
+	e.retryDelay = w.delay
+}
+
+// WithResource method
+// ResourceInfo of NotFound status
+func WithResource(resourceType, resourceName string) Option {
+	return withResource{resourceType: resourceType, resourceName: resourceName}
+}
+
+type withResource struct {
+	resourceType string
+	resourceName string
+}
+
+// Apply method
+func (w withResource) Apply(e *KindError) {
+	e.resourceType = w.resourceType
+	e.resourceName = w.resourceName
+}
+
+// grpcStatus attaches ErrorInfo, RetryInfo for Unavailable and ResourceInfo for NotFound to status
+func (e KindError) grpcStatus(code codes.Code, message string, details ...proto.Message) error {
+	details = append([]proto.Message{&errdetails.ErrorInfo{Reason: e.GetName(), Domain: e.system}}, details...)
+	switch code {
+	case codes.Unavailable:
+		delay := e.retryDelay
+		if delay == 0 {
+			delay = DefaultRetryDelay
+		}
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	case codes.NotFound:
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: e.resourceType,
+			ResourceName: e.resourceName,
+			Description:  e.err.Error(),
+		})
+	}
+	st := status.New(code, message)
+	if detailed, err := st.WithDetails(details...); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+var grpcErrorConstructors = map[string]func(err error, st *status.Status) IGRPCErrorReport{
+	ErrProcessAuthenticate: func(err error, _ *status.Status) IGRPCErrorReport { return NewErrAuthenticate(err) },
+	ErrDbAlreadyExists:     func(err error, _ *status.Status) IGRPCErrorReport { return NewErrDBAlreadyExists(err) },
+	ErrDbConnection:        func(err error, _ *status.Status) IGRPCErrorReport { return NewErrDBConnection(err) },
+	ErrDbDisconnection:     func(err error, _ *status.Status) IGRPCErrorReport { return NewErrDBDisconnection(err) },
+	ErrDbExecute:           func(err error, _ *status.Status) IGRPCErrorReport { return NewErrDBExecute(err) },
+	ErrDbRowNotFound:       func(err error, _ *status.Status) IGRPCErrorReport { return NewErrDBRowNotFound(err) },
+	ErrDbUpdateNoEffect:    func(err error, _ *status.Status) IGRPCErrorReport { return NewErrDBUpdateNoEffect(err) },
+	ErrProcessExecute:      func(err error, _ *status.Status) IGRPCErrorReport { return NewErrExecute(err) },
+	ErrGrpcConnection:      func(err error, _ *status.Status) IGRPCErrorReport { return NewErrGRPCConnection(err, nil) },
+	ErrGrpcExecute:         func(err error, _ *status.Status) IGRPCErrorReport { return NewErrGRPCExecute(err) },
+	ErrProcessInvalidArgument: func(err error, st *status.Status) IGRPCErrorReport {
+		return NewErrInvalidArgument(err, fieldViolations(st)...)
+	},
+	ErrJsonMarshal:           func(err error, _ *status.Status) IGRPCErrorReport { return NewErrJSONMarshal(err) },
+	ErrJsonUnmarshal:         func(err error, _ *status.Status) IGRPCErrorReport { return NewErrJSONUnmarshal(err) },
+	ErrJwtExecute:            func(err error, _ *status.Status) IGRPCErrorReport { return NewErrJWTExecute(err) },
+	ErrDataNotFound:          func(err error, _ *status.Status) IGRPCErrorReport { return NewErrNotFound(err) },
+	ErrProcessPermissionDeny: func(err error, _ *status.Status) IGRPCErrorReport { return NewErrPermissionDeny(err) },
+	ErrProcessServerExecute:  func(err error, _ *status.Status) IGRPCErrorReport { return NewErrServerExecute(err) },
+	ErrProcessVariable:       func(err error, _ *status.Status) IGRPCErrorReport { return NewErrVariable(err) },
+}
+
+// FromGRPCError method
+// converts status error received by client into errorhandler type by ErrorInfo reason,
+// registered kinds are converted into *KindError, false when err is not status error
+func FromGRPCError(err error) (IGRPCErrorReport, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.OK {
+		return nil, false
+	}
+	info := &errdetails.ErrorInfo{}
+	for _, detail := range st.Details() {
+		if i, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = i
+			break
+		}
+	}
+	cause := errors.New(st.Message())
+	var report IGRPCErrorReport
+	if constructor, exist := grpcErrorConstructors[info.GetReason()]; exist {
+		report = constructor(cause, st)
+	} else if kind, exist := LookupKind(info.GetReason()); exist {
+		report = New(kind, cause)
+	} else {
+		report = New(Kind{Name: info.GetReason(), HTTPStatus: httpStatus(st.Code()), GRPCCode: st.Code()}, cause)
+	}
+	report.SetSystem(info.GetDomain())
+	return report, true
+}
+
+func fieldViolations(st *status.Status) []FieldViolation {
+	violations := make([]FieldViolation, 0)
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				violations = append(violations, FieldViolation{Field: violation.GetField(), Description: violation.GetDescription()})
+			}
+		}
+	}
+	return violations
+}
+
+func badRequest(violations []FieldViolation) *errdetails.BadRequest {
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+	return badRequest
+}
+
+// httpStatus maps gRPC code to HTTP status like grpc-gateway
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,135 @@
+package errorhandler
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type GRPCDetailsSuite struct {
+	suite.Suite
+}
+
+func (suite *GRPCDetailsSuite) SetupTest() {
+	observedZapCore, _ := observer.New(zap.WarnLevel)
+	logger = zap.New(observedZapCore)
+}
+
+func (suite *GRPCDetailsSuite) details(err error) []interface{} {
+	st, ok := status.FromError(err)
+	suite.True(ok)
+	return st.Details()
+}
+
+func (suite *GRPCDetailsSuite) TestErrorInfo() {
+	var errContent error
+	func() {
+		defer PanicGRPCErrorHandler(&errContent, "Mock system", "prefix")
+		panic(NewErrPermissionDeny(errors.New("got error")))
+	}()
+	suite.Equal("rpc error: code = PermissionDenied desc = prefix: got error", errContent.Error())
+	details := suite.details(errContent)
+	suite.Len(details, 1)
+	suite.Equal(ErrProcessPermissionDeny, details[0].(*errdetails.ErrorInfo).GetReason())
+	suite.Equal("Mock system", details[0].(*errdetails.ErrorInfo).GetDomain())
+}
+
+func (suite *GRPCDetailsSuite) TestRetryInfo() {
+	var errContent error
+	NewErrDBConnection(errors.New("got error")).GRPCReport(&errContent, "prefix")
+	details := suite.details(errContent)
+	suite.Len(details, 2)
+	suite.Equal(DefaultRetryDelay, details[1].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+
+	New(KindGRPCConnection, errors.New("got error"), WithRetryDelay(5*time.Second)).GRPCReport(&errContent, "prefix")
+	suite.Equal(5*time.Second, suite.details(errContent)[1].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+
+	NewErrGRPCConnection(errors.New("got error"), nil).GRPCReport(&errContent, "prefix")
+	suite.IsType(&errdetails.RetryInfo{}, suite.details(errContent)[1])
+
+	NewErrDBExecute(errors.Wrap(ErrNoRows, "select")).GRPCReport(&errContent, "prefix")
+	suite.IsType(&errdetails.ResourceInfo{}, suite.details(errContent)[1])
+}
+
+func (suite *GRPCDetailsSuite) TestResourceInfo() {
+	var errContent error
+	New(KindNotFound, errors.New("user not found"), WithResource("user", "users/1")).GRPCReport(&errContent, "prefix")
+	details := suite.details(errContent)
+	suite.Len(details, 2)
+	info := details[1].(*errdetails.ResourceInfo)
+	suite.Equal("user", info.GetResourceType())
+	suite.Equal("users/1", info.GetResourceName())
+	suite.Equal("user not found", info.GetDescription())
+}
+
+func (suite *GRPCDetailsSuite) TestBadRequest() {
+	var errContent error
+	NewErrInvalidArgument(ErrInvalidArguments, FieldViolation{Field: "name", Description: "required"}).GRPCReport(&errContent, "prefix")
+	suite.Equal(codes.InvalidArgument, status.Code(errContent))
+	details := suite.details(errContent)
+	suite.Len(details, 2)
+	suite.Equal("name", details[1].(*errdetails.BadRequest).GetFieldViolations()[0].GetField())
+
+	NewErrInvalidArgument(ErrInvalidArguments).GRPCReport(&errContent, "prefix")
+	suite.Len(suite.details(errContent), 1)
+}
+
+func (suite *GRPCDetailsSuite) TestFromGRPCError() {
+	var errContent error
+	NewErrInvalidArgument(ErrInvalidArguments, FieldViolation{Field: "name", Description: "required"}).SetSystem("Mock system").(IGRPCErrorReport).GRPCReport(&errContent, "prefix")
+	report, ok := FromGRPCError(errContent)
+	suite.True(ok)
+	suite.IsType(&ErrInvalidArgument{}, report)
+	suite.Equal("prefix: invalid arguments", report.GetError().Error())
+	suite.Equal([]FieldViolation{{Field: "name", Description: "required"}}, report.(*ErrInvalidArgument).GetFieldViolations())
+	suite.Equal("Mock system", report.(*ErrInvalidArgument).GetSystem())
+
+	NewErrNotFound(errors.New("got error")).GRPCReport(&errContent, "prefix")
+	report, ok = FromGRPCError(errContent)
+	suite.True(ok)
+	suite.IsType(&ErrNotFound{}, report)
+
+	kind := Kind{Name: "errQuotaExceeded", HTTPStatus: http.StatusTooManyRequests, GRPCCode: codes.ResourceExhausted}
+	suite.NoError(RegisterKind(kind))
+	defer func() {
+		kindsMutex.Lock()
+		defer kindsMutex.Unlock()
+		delete(kinds, kind.Name)
+	}()
+	New(kind, errors.New("got error")).GRPCReport(&errContent, "prefix")
+	report, ok = FromGRPCError(errContent)
+	suite.True(ok)
+	suite.Equal(kind, report.(*KindError).GetKind())
+
+	report, ok = FromGRPCError(status.Error(codes.Unavailable, "connection refused"))
+	suite.True(ok)
+	suite.Equal(Kind{HTTPStatus: http.StatusServiceUnavailable, GRPCCode: codes.Unavailable}, report.(*KindError).GetKind())
+	suite.Equal("connection refused", report.GetError().Error())
+
+	_, ok = FromGRPCError(nil)
+	suite.False(ok)
+	_, ok = FromGRPCError(errors.New("got error"))
+	suite.False(ok)
+}
+
+func (suite *GRPCDetailsSuite) TestHTTPStatus() {
+	suite.Equal(499, httpStatus(codes.Canceled))
+	suite.Equal(http.StatusBadRequest, httpStatus(codes.FailedPrecondition))
+	suite.Equal(http.StatusGatewayTimeout, httpStatus(codes.DeadlineExceeded))
+	suite.Equal(http.StatusConflict, httpStatus(codes.Aborted))
+	suite.Equal(http.StatusForbidden, httpStatus(codes.PermissionDenied))
+	suite.Equal(http.StatusUnauthorized, httpStatus(codes.Unauthenticated))
+	suite.Equal(http.StatusNotImplemented, httpStatus(codes.Unimplemented))
+	suite.Equal(http.StatusInternalServerError, httpStatus(codes.Internal))
+}
+
+func TestGRPCDetailsSuite(t *testing.T) {
+	suite.Run(t, new(GRPCDetailsSuite))
+}
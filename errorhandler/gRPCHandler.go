@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/cockroachdb/errors"
 	"github.com/gogo/status"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 )
 
@@ -12,20 +13,51 @@ type IGRPCErrorReport interface {
 	GRPCReport(errContent *error, prefixMessage string)
 }
 
+// GRPCError method
+// converts err returned by gRPC handler into status error without panic,
+// IGRPCErrorReport in chain is reported and converted by GRPCReport
+func GRPCError(err error, system, prefixMessage string) error {
+	if err == nil {
+		return nil
+	}
+	var report IGRPCErrorReport
+	if errors.As(err, &report) {
+		report.SetSystem(system).Report(prefixMessage)
+		var errContent error
+		report.GRPCReport(&errContent, prefixMessage)
+		return errContent
+	}
+	var errContent error
+	fromStatusError(&errContent, err, prefixMessage)
+	return errContent
+}
+
 func PanicGRPCErrorHandler(errContent *error, system, prefixMessage string) {
 	if err := recover(); err != nil {
-		switch err.(type) {
-		case IGRPCErrorReport:
-			err.(IGRPCErrorReport).SetSystem(system).Report(prefixMessage)
-			err.(IGRPCErrorReport).GRPCReport(errContent, prefixMessage)
-			return
-		case error:
-			fromStatusError(errContent, err.(error), prefixMessage)
-			return
-		default:
-			*errContent = status.Error(codes.Unknown, errors.Wrap(fmt.Errorf("%v", err), prefixMessage).Error())
-		}
+		*errContent = GRPCPanicError(err, system, prefixMessage)
+	}
+}
+
+// GRPCPanicError method
+// converts value recovered from panic into status error, IGRPCErrorReport is reported and converted by GRPCReport,
+// other values are logged with stack trace
+func GRPCPanicError(recovered interface{}, system, prefixMessage string) error {
+	var errContent error
+	var report IGRPCErrorReport
+	if e, ok := recovered.(error); ok && errors.As(e, &report) {
+		report.SetSystem(system).Report(prefixMessage)
+		report.GRPCReport(&errContent, prefixMessage)
+		return errContent
+	}
+	switch err := recovered.(type) {
+	case error:
+		logger.Error(prefixMessage, zap.Error(err), zap.Stack("stacktrace"))
+		fromStatusError(&errContent, err, prefixMessage)
+	default:
+		logger.Error(prefixMessage, zap.Any("data", err), zap.Stack("stacktrace"))
+		errContent = status.Error(codes.Unknown, errors.Wrap(fmt.Errorf("%v", err), prefixMessage).Error())
 	}
+	return errContent
 }
 
 func fromStatusError(errContent *error, input error, prefixMessage string) {
@@ -1,15 +1,25 @@
 package errorhandler
 
 import (
+	"fmt"
 	"github.com/cockroachdb/errors"
 	"github.com/gogo/status"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/grpc/codes"
 	"testing"
 )
 
 type GRPCHandlerSuite struct {
 	suite.Suite
+	obLog *observer.ObservedLogs
+}
+
+func (suite *GRPCHandlerSuite) SetupTest() {
+	observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+	logger = zap.New(observedZapCore)
+	suite.obLog = observedLogs
 }
 
 func (suite *GRPCHandlerSuite) TestPanicGRPCErrorHandlerNormalStringError() {
@@ -54,6 +64,47 @@ func (suite *GRPCHandlerSuite) TestPanicGRPCErrorHandlerStatusError() {
 	}
 }
 
+func (suite *GRPCHandlerSuite) TestPanicGRPCErrorHandlerStackTrace() {
+	var errContent error
+	func() {
+		defer PanicGRPCErrorHandler(&errContent, "MockGRPCHandler", "Test error handler")
+		panic(errors.New("got error"))
+	}()
+	suite.Equal(1, suite.obLog.Len())
+	suite.Equal(zap.ErrorLevel, suite.obLog.All()[0].Level)
+	suite.Contains(suite.obLog.All()[0].ContextMap()["stacktrace"], "gRPCHandler_test.go")
+}
+
+func (suite *GRPCHandlerSuite) TestGRPCError() {
+	suite.NoError(GRPCError(nil, "MockGRPCHandler", "Test error handler"))
+
+	err := GRPCError(fmt.Errorf("wrapped: %w", NewErrNotFound(errors.New("got error"))), "MockGRPCHandler", "Test error handler")
+	suite.Equal(codes.NotFound, status.Code(err))
+	suite.Equal("Test error handler: got error", status.Convert(err).Message())
+	suite.Equal(1, suite.obLog.Len())
+
+	err = GRPCError(status.Error(codes.Aborted, "got error"), "MockGRPCHandler", "Test error handler")
+	suite.Equal("rpc error: code = Aborted desc = Test error handler: got error", err.Error())
+
+	err = GRPCError(errors.New("got error"), "MockGRPCHandler", "Test error handler")
+	suite.Equal("rpc error: code = Unknown desc = Test error handler: got error", err.Error())
+	suite.Equal(1, suite.obLog.Len())
+}
+
+func (suite *GRPCHandlerSuite) TestGRPCPanicError() {
+	err := GRPCPanicError(NewErrDBRowNotFound(errors.New("got error")), "MockGRPCHandler", "Test error handler")
+	suite.Equal(codes.NotFound, status.Code(err))
+	suite.Equal("Test error handler: got error", status.Convert(err).Message())
+
+	err = GRPCPanicError(status.Error(codes.Aborted, "got error"), "MockGRPCHandler", "Test error handler")
+	suite.Equal("rpc error: code = Aborted desc = Test error handler: got error", err.Error())
+
+	err = GRPCPanicError(100, "MockGRPCHandler", "Test error handler")
+	suite.Equal("rpc error: code = Unknown desc = Test error handler: 100", err.Error())
+	suite.Equal(3, suite.obLog.Len())
+	suite.Contains(suite.obLog.All()[2].ContextMap()["stacktrace"], "TestGRPCPanicError")
+}
+
 func TestGRPCHandlerSuite(t *testing.T) {
 	suite.Run(t, new(GRPCHandlerSuite))
 }
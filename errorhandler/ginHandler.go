@@ -2,7 +2,9 @@ package errorhandler
 
 import (
 	"fmt"
+	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 	"net/http"
 )
 
@@ -11,19 +13,47 @@ type IGinErrorReport interface {
 	GinReport(c *gin.Context)
 }
 
+// GinErrorHandler method
+// renders last error of c.Errors after handlers when response not written,
+// IGinErrorReport in chain is reported and rendered by GinReport, other errors respond 500
+func GinErrorHandler(system, prefixMessage string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		last := len(c.Errors) - 1
+		if last < 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors[last].Err
+		var report IGinErrorReport
+		if errors.As(err, &report) {
+			// GinReport records its underlying error by AbortWithError
+			c.Errors = c.Errors[:last]
+			report.SetSystem(system).Report(prefixMessage)
+			report.GinReport(c)
+			return
+		}
+		logger.Error(prefixMessage, zap.Error(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}
+}
+
 func GinPanicErrorHandler(system, prefixMessage string) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				switch err.(type) {
-				case IGinErrorReport:
-					err.(IGinErrorReport).SetSystem(system).Report(prefixMessage)
-					err.(IGinErrorReport).GinReport(c)
+				var report IGinErrorReport
+				if e, ok := err.(error); ok && errors.As(e, &report) {
+					report.SetSystem(system).Report(prefixMessage)
+					report.GinReport(c)
 					return
+				}
+				switch err.(type) {
 				case error:
+					logger.Error(prefixMessage, zap.Error(err.(error)), zap.Stack("stacktrace"))
 					c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("%s: %w", prefixMessage, err.(error)))
 					return
 				default:
+					logger.Error(prefixMessage, zap.Any("data", err), zap.Stack("stacktrace"))
 					c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("%s: %s", prefixMessage, err))
 				}
 			}
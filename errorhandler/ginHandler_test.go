@@ -1,6 +1,7 @@
 package errorhandler
 
 import (
+	"fmt"
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -74,6 +75,65 @@ func (suite *GinHandlerSuite) TestGinPanicErrorHandlerStringError() {
 	suite.Equal(http.StatusInternalServerError, result.StatusCode)
 }
 
+func (suite *GinHandlerSuite) TestGinPanicErrorHandlerStackTrace() {
+	gin.SetMode(gin.ReleaseMode)
+	route := gin.New()
+	route.Use(GinPanicErrorHandler("Mock Gin", "error Gin mock"))
+	route.GET("/", func(c *gin.Context) {
+		panic("got error")
+	})
+	w := httptest.NewRecorder()
+	route.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	require.Equal(suite.T(), 1, suite.obLog.Len())
+	suite.Equal(zap.ErrorLevel, suite.obLog.All()[0].Level)
+	suite.Contains(suite.obLog.All()[0].ContextMap()["stacktrace"], "ginHandler_test.go")
+}
+
+func (suite *GinHandlerSuite) TestGinErrorHandler() {
+	gin.SetMode(gin.ReleaseMode)
+	route := gin.New()
+	route.Use(GinErrorHandler("Mock Gin", "error Gin mock"))
+	route.GET("/report", func(c *gin.Context) {
+		_ = c.Error(errors.New("first error"))
+		_ = c.Error(fmt.Errorf("wrapped: %w", NewErrNotFound(errors.New("got error"))))
+	})
+	route.GET("/error", func(c *gin.Context) {
+		_ = c.Error(errors.New("got error"))
+	})
+	route.GET("/written", func(c *gin.Context) {
+		_ = c.Error(NewErrNotFound(errors.New("got error")))
+		c.Status(http.StatusAccepted)
+		c.Writer.WriteHeaderNow()
+	})
+	route.GET("/ok", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	var errs []string
+	request := func(path string) int {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, path, nil)
+		route.HandleContext(c)
+		errs = c.Errors.Errors()
+		return w.Code
+	}
+	suite.Equal(http.StatusNotFound, request("/report"))
+	suite.Equal([]string{"first error", "got error"}, errs)
+	require.Equal(suite.T(), 1, suite.obLog.Len())
+	suite.Equal(zap.WarnLevel, suite.obLog.All()[0].Level)
+
+	suite.Equal(http.StatusInternalServerError, request("/error"))
+	suite.Equal([]string{"got error"}, errs)
+	require.Equal(suite.T(), 2, suite.obLog.Len())
+	suite.Equal(zap.ErrorLevel, suite.obLog.All()[1].Level)
+
+	suite.Equal(http.StatusAccepted, request("/written"))
+	suite.Equal(http.StatusNoContent, request("/ok"))
+	suite.Equal(2, suite.obLog.Len())
+}
+
 func TestGinHandlerSuite(t *testing.T) {
 	suite.Run(t, new(GinHandlerSuite))
 }
@@ -2,6 +2,7 @@ package errorhandler
 
 import (
 	"fmt"
+	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
 	"sync"
 )
@@ -14,19 +15,34 @@ type IErrorReport interface {
 	Report(prefix string)
 }
 
+// Report method
+// reports err by IErrorReport in chain, other errors are logged as error
+func Report(err error, system, prefixMessage string) {
+	if err == nil {
+		return
+	}
+	var errReportInstance IErrorReport
+	if errors.As(err, &errReportInstance) {
+		errReportInstance.SetSystem(system).Report(prefixMessage)
+		return
+	}
+	logger.Error(prefixMessage, zap.Error(err))
+}
+
 func PanicErrorHandler(system, prefixMessage string) {
 	if err := recover(); err != nil {
-		if errReportInstance, okIErrorReport := err.(IErrorReport); okIErrorReport {
+		var errReportInstance IErrorReport
+		if e, ok := err.(error); ok && errors.As(e, &errReportInstance) {
 			errReportInstance.SetSystem(system).Report("")
 			return
 		}
 		switch err.(type) {
 		case error:
-			logger.Error(prefixMessage, zap.Error(err.(error)))
+			logger.Error(prefixMessage, zap.Error(err.(error)), zap.Stack("stacktrace"))
 		case string:
-			logger.Error(fmt.Sprintf("%s: %s", prefixMessage, err))
+			logger.Error(fmt.Sprintf("%s: %s", prefixMessage, err), zap.Stack("stacktrace"))
 		default:
-			logger.Error(prefixMessage, zap.Any("data", err))
+			logger.Error(prefixMessage, zap.Any("data", err), zap.Stack("stacktrace"))
 		}
 	}
 }
@@ -99,6 +99,29 @@ func (suite *HandlerSuite) TestPanicErrorHandlerPanicNormalStringError() {
 	suite.Equal("Mock test : got error", firstLog.Message)
 }
 
+func (suite *HandlerSuite) TestPanicErrorHandlerStackTrace() {
+	func() {
+		defer PanicErrorHandler("Mock Root System", "Mock test")
+		panic(struct{}{})
+	}()
+	require.Equal(suite.T(), 1, suite.obLog.Len())
+	suite.Contains(suite.obLog.All()[0].ContextMap()["stacktrace"], "handler_test.go")
+}
+
+func (suite *HandlerSuite) TestReport() {
+	Report(nil, "Mock Root System", "Mock test")
+	suite.Equal(0, suite.obLog.Len())
+
+	Report(fmt.Errorf("close: %w", NewErrDBDisconnection(errors.New("got error"))), "Mock Root System", "Mock test")
+	require.Equal(suite.T(), 1, suite.obLog.Len())
+	suite.Equal(zap.WarnLevel, suite.obLog.All()[0].Level)
+
+	Report(errors.New("got error"), "Mock Root System", "Mock test")
+	require.Equal(suite.T(), 2, suite.obLog.Len())
+	suite.Equal(zap.ErrorLevel, suite.obLog.All()[1].Level)
+	suite.Equal("Mock test", suite.obLog.All()[1].Message)
+}
+
 func TestHandlerSuite(t *testing.T) {
 	suite.Run(t, new(HandlerSuite))
 }
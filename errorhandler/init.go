@@ -1,7 +1,16 @@
 package errorhandler
 
-import "github.com/justdomepaul/toolbox/zap"
+import (
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"go.uber.org/zap"
+)
 
 var (
-	logger = zap.Logger
+	logger = zapTool.Logger
 )
+
+// SetLogger method
+// replaces logger of reports, default zap.Logger of toolbox, e.g. masking logger built by config.Core with LoggerMasking
+func SetLogger(l *zap.Logger) {
+	logger = l
+}
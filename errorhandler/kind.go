@@ -0,0 +1,260 @@
+package errorhandler
+
+import (
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kind type
+// describes error category, zero HTTPStatus reports 500, codes.OK GRPCCode reports codes.Unknown
+type Kind struct {
+	Name       string
+	HTTPStatus int
+	GRPCCode   codes.Code
+	Level      zapcore.Level
+}
+
+var (
+	KindAuthenticate     = Kind{Name: ErrProcessAuthenticate, HTTPStatus: http.StatusUnauthorized, GRPCCode: codes.Unauthenticated, Level: zapcore.WarnLevel}
+	KindDBAlreadyExists  = Kind{Name: ErrDbAlreadyExists, HTTPStatus: http.StatusConflict, GRPCCode: codes.AlreadyExists, Level: zapcore.WarnLevel}
+	KindDBConnection     = Kind{Name: ErrDbConnection, HTTPStatus: http.StatusServiceUnavailable, GRPCCode: codes.Unavailable, Level: zapcore.WarnLevel}
+	KindDBDisconnection  = Kind{Name: ErrDbDisconnection, HTTPStatus: http.StatusServiceUnavailable, GRPCCode: codes.Unavailable, Level: zapcore.WarnLevel}
+	KindDBExecute        = Kind{Name: ErrDbExecute, HTTPStatus: http.StatusConflict, GRPCCode: codes.FailedPrecondition, Level: zapcore.WarnLevel}
+	KindDBRowNotFound    = Kind{Name: ErrDbRowNotFound, HTTPStatus: http.StatusNotFound, GRPCCode: codes.NotFound, Level: zapcore.WarnLevel}
+	KindDBUpdateNoEffect = Kind{Name: ErrDbUpdateNoEffect, HTTPStatus: http.StatusNotFound, GRPCCode: codes.NotFound, Level: zapcore.WarnLevel}
+	KindDBAborted        = Kind{Name: ErrDbAborted, HTTPStatus: http.StatusConflict, GRPCCode: codes.Aborted, Level: zapcore.WarnLevel}
+	KindDBTimeout        = Kind{Name: ErrDbTimeout, HTTPStatus: http.StatusGatewayTimeout, GRPCCode: codes.DeadlineExceeded, Level: zapcore.WarnLevel}
+	KindDBCanceled       = Kind{Name: ErrDbCanceled, HTTPStatus: 499, GRPCCode: codes.Canceled, Level: zapcore.WarnLevel}
+	KindExecute          = Kind{Name: ErrProcessExecute, HTTPStatus: http.StatusUnprocessableEntity, GRPCCode: codes.FailedPrecondition, Level: zapcore.WarnLevel}
+	KindGRPCConnection   = Kind{Name: ErrGrpcConnection, HTTPStatus: http.StatusServiceUnavailable, GRPCCode: codes.Unavailable, Level: zapcore.WarnLevel}
+	KindGRPCExecute      = Kind{Name: ErrGrpcExecute, HTTPStatus: http.StatusUnprocessableEntity, GRPCCode: codes.FailedPrecondition, Level: zapcore.WarnLevel}
+	KindInvalidArgument  = Kind{Name: ErrProcessInvalidArgument, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Level: zapcore.WarnLevel}
+	KindJSONMarshal      = Kind{Name: ErrJsonMarshal, HTTPStatus: http.StatusInternalServerError, GRPCCode: codes.InvalidArgument, Level: zapcore.WarnLevel}
+	KindJSONUnmarshal    = Kind{Name: ErrJsonUnmarshal, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Level: zapcore.WarnLevel}
+	KindJWTExecute       = Kind{Name: ErrJwtExecute, HTTPStatus: http.StatusForbidden, GRPCCode: codes.PermissionDenied, Level: zapcore.WarnLevel}
+	KindNotFound         = Kind{Name: ErrDataNotFound, HTTPStatus: http.StatusNotFound, GRPCCode: codes.NotFound, Level: zapcore.WarnLevel}
+	KindPermissionDeny   = Kind{Name: ErrProcessPermissionDeny, HTTPStatus: http.StatusForbidden, GRPCCode: codes.PermissionDenied, Level: zapcore.WarnLevel}
+	KindServerExecute    = Kind{Name: ErrProcessServerExecute, HTTPStatus: http.StatusInternalServerError, GRPCCode: codes.FailedPrecondition, Level: zapcore.WarnLevel}
+	KindVariable         = Kind{Name: ErrProcessVariable, HTTPStatus: http.StatusBadRequest, GRPCCode: codes.InvalidArgument, Level: zapcore.WarnLevel}
+)
+
+var (
+	ErrKindRegistered   = errors.New("error kind already registered")
+	ErrKindNameRequired = errors.New("error kind name required")
+	kinds               = map[string]Kind{}
+	kindsMutex          sync.RWMutex
+)
+
+func init() {
+	for _, kind := range []Kind{
+		KindAuthenticate, KindDBAlreadyExists, KindDBConnection, KindDBDisconnection, KindDBExecute, KindDBRowNotFound,
+		KindDBUpdateNoEffect, KindDBAborted, KindDBTimeout, KindDBCanceled, KindExecute, KindGRPCConnection,
+		KindGRPCExecute, KindInvalidArgument, KindJSONMarshal, KindJSONUnmarshal, KindJWTExecute, KindNotFound, KindPermissionDeny, KindServerExecute, KindVariable,
+	} {
+		kinds[kind.Name] = kind
+	}
+}
+
+// RegisterKind method
+// registers application kind, name must be unique
+func RegisterKind(kind Kind) error {
+	if kind.Name == "" {
+		return ErrKindNameRequired
+	}
+	kindsMutex.Lock()
+	defer kindsMutex.Unlock()
+	if _, exist := kinds[kind.Name]; exist {
+		return fmt.Errorf("%w: %s", ErrKindRegistered, kind.Name)
+	}
+	kinds[kind.Name] = kind
+	return nil
+}
+
+// LookupKind method
+func LookupKind(name string) (Kind, bool) {
+	kindsMutex.RLock()
+	defer kindsMutex.RUnlock()
+	kind, exist := kinds[name]
+	return kind, exist
+}
+
+// Kinds method
+// registered kinds sorted by name
+func Kinds() []Kind {
+	kindsMutex.RLock()
+	defer kindsMutex.RUnlock()
+	result := make([]Kind, 0, len(kinds))
+	for _, kind := range kinds {
+		result = append(result, kind)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Option interface
+type Option interface {
+	Apply(*KindError)
+}
+
+// WithSystem method
+func WithSystem(system string) Option {
+	return withSystem{system: system}
+}
+
+type withSystem struct {
+	system string
+}
+
+// Apply method
+func (w withSystem) Apply(e *KindError) {
+	e.system = w.system
+}
+
+// WithFields method
+// fields logged by Report
+func WithFields(fields ...zap.Field) Option {
+	return withFields{fields: fields}
+}
+
+type withFields struct {
+	fields []zap.Field
+}
+
+// Apply method
+func (w withFields) Apply(e *KindError) {
+	e.fields = append(e.fields, w.fields...)
+}
+
+// KindError type
+// reports error by its Kind, embedded by named error types
+type KindError struct {
+	kind   Kind
+	system string
+	err    error
+	fields []zap.Field
+	stack  []uintptr
+
+	retryDelay   time.Duration
+	resourceType string
+	resourceName string
+}
+
+// New method
+func New(kind Kind, err error, options ...Option) *KindError {
+	e := newKindError(kind, err)
+	for _, option := range options {
+		option.Apply(&e)
+	}
+	return &e
+}
+
+// newKindError captures stack trace from caller of constructor
+func newKindError(kind Kind, err error) KindError {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	return KindError{kind: kind, err: err, stack: pcs[:n]}
+}
+
+// SetSystem method
+func (e *KindError) SetSystem(system string) IErrorReport {
+	if e.system == "" {
+		e.system = system
+	}
+	return e
+}
+
+// GetSystem method
+func (e KindError) GetSystem() string {
+	return e.system
+}
+
+// GetKind method
+func (e KindError) GetKind() Kind {
+	return e.kind
+}
+
+// GetName method
+func (e KindError) GetName() string {
+	return e.kind.Name
+}
+
+// GetError method
+func (e KindError) GetError() error {
+	return e.err
+}
+
+// Unwrap method
+func (e KindError) Unwrap() error {
+	return e.err
+}
+
+// Error method
+func (e KindError) Error() string {
+	return "[ERROR]: " + e.err.Error()
+}
+
+// StackTrace method
+// stack trace captured when error constructed
+func (e KindError) StackTrace() string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Report method
+func (e KindError) Report(prefix string) {
+	if ce := logger.Check(e.kind.Level, prefix); ce != nil {
+		fields := append([]zap.Field{zap.Error(e.err)}, e.fields...)
+		ce.Write(append(fields, e.stackTraceField())...)
+	}
+}
+
+func (e KindError) stackTraceField() zap.Field {
+	if len(e.stack) == 0 {
+		return zap.Skip()
+	}
+	return zap.String("stacktrace", e.StackTrace())
+}
+
+// GinReport method
+func (e KindError) GinReport(c *gin.Context) {
+	c.AbortWithError(e.httpStatus(), e.err)
+}
+
+// GRPCReport method
+func (e KindError) GRPCReport(errContent *error, prefixMessage string) {
+	*errContent = e.grpcStatus(e.grpcCode(), errors.Wrap(e.err, prefixMessage).Error())
+}
+
+func (e KindError) httpStatus() int {
+	if e.kind.HTTPStatus == 0 {
+		return http.StatusInternalServerError
+	}
+	return e.kind.HTTPStatus
+}
+
+func (e KindError) grpcCode() codes.Code {
+	if e.kind.GRPCCode == codes.OK {
+		return codes.Unknown
+	}
+	return e.kind.GRPCCode
+}
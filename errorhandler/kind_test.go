@@ -0,0 +1,176 @@
+package errorhandler
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type KindSuite struct {
+	suite.Suite
+	obLog *observer.ObservedLogs
+	kind  Kind
+}
+
+func (suite *KindSuite) SetupTest() {
+	observedZapCore, observedLogs := observer.New(zap.InfoLevel)
+	logger = zap.New(observedZapCore)
+	suite.obLog = observedLogs
+	suite.kind = Kind{Name: "errQuotaExceeded", HTTPStatus: http.StatusTooManyRequests, GRPCCode: codes.ResourceExhausted, Level: zapcore.ErrorLevel}
+}
+
+func (suite *KindSuite) TestNew() {
+	err := New(suite.kind, errors.New("got error"), WithSystem("Mock system"), WithFields(zap.Int("quota", 10)))
+	suite.Equal(suite.kind, err.GetKind())
+	suite.Equal("errQuotaExceeded", err.GetName())
+	suite.Equal("Mock system", err.GetSystem())
+	suite.Equal("Mock system", err.SetSystem("other").(*KindError).GetSystem())
+	suite.Equal("got error", err.GetError().Error())
+	suite.Equal("[ERROR]: got error", err.Error())
+	suite.Equal("got error", err.Unwrap().Error())
+	suite.Implements((*IGinErrorReport)(nil), err)
+	suite.Implements((*IGRPCErrorReport)(nil), err)
+}
+
+func (suite *KindSuite) TestReport() {
+	New(suite.kind, errors.New("got error"), WithFields(zap.Int("quota", 10))).Report("prefix")
+	require.Equal(suite.T(), 1, suite.obLog.Len())
+	firstLog := suite.obLog.All()[0]
+	suite.Equal(zapcore.ErrorLevel, firstLog.Level)
+	suite.Equal("prefix", firstLog.Message)
+	suite.Equal("got error", firstLog.ContextMap()["error"])
+	suite.Equal(int64(10), firstLog.ContextMap()["quota"])
+
+	New(Kind{Name: "errDebug", Level: zapcore.DebugLevel}, errors.New("got error")).Report("prefix")
+	suite.Equal(1, suite.obLog.Len())
+}
+
+func (suite *KindSuite) TestIsAs() {
+	err := fmt.Errorf("get user: %w", NewErrNotFound(fmt.Errorf("select: %w", ErrNoRows)))
+	suite.True(errors.Is(err, ErrNoRows))
+	suite.True(stdErrors.Is(err, ErrNoRows))
+	var notFound *ErrNotFound
+	suite.True(stdErrors.As(err, &notFound))
+	suite.Equal(KindNotFound, notFound.GetKind())
+	var report IGRPCErrorReport
+	suite.True(errors.As(err, &report))
+	suite.Equal(ErrDataNotFound, report.GetName())
+}
+
+func (suite *KindSuite) TestStackTrace() {
+	err := NewErrNotFound(errors.New("got error"))
+	suite.True(strings.HasPrefix(err.StackTrace(), "github.com/justdomepaul/toolbox/errorhandler.(*KindSuite).TestStackTrace\n\t"))
+	suite.Contains(err.StackTrace(), "kind_test.go:")
+	suite.True(strings.HasPrefix(New(suite.kind, errors.New("got error")).StackTrace(), "github.com/justdomepaul/toolbox/errorhandler.(*KindSuite).TestStackTrace\n\t"))
+
+	err.Report("prefix")
+	require.Equal(suite.T(), 1, suite.obLog.Len())
+	suite.Equal(err.StackTrace(), suite.obLog.All()[0].ContextMap()["stacktrace"])
+
+	KindError{kind: suite.kind, err: errors.New("got error")}.Report("prefix")
+	suite.NotContains(suite.obLog.All()[1].ContextMap(), "stacktrace")
+}
+
+func (suite *KindSuite) TestWrappedPanic() {
+	var errContent error
+	func() {
+		defer PanicGRPCErrorHandler(&errContent, "MockGRPCHandler", "Test error handler")
+		panic(fmt.Errorf("get user: %w", NewErrNotFound(errors.New("got error"))))
+	}()
+	suite.Equal(codes.NotFound, status.Code(errContent))
+
+	gin.SetMode(gin.ReleaseMode)
+	route := gin.New()
+	route.Use(GinPanicErrorHandler("Mock Gin", "error Gin mock"))
+	route.GET("/", func(c *gin.Context) {
+		panic(fmt.Errorf("get user: %w", NewErrNotFound(errors.New("got error"))))
+	})
+	w := httptest.NewRecorder()
+	route.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	suite.Equal(http.StatusNotFound, w.Code)
+
+	func() {
+		defer PanicErrorHandler("Mock system", "prefix")
+		panic(fmt.Errorf("get user: %w", NewErrNotFound(errors.New("got error"))))
+	}()
+	suite.Equal(3, suite.obLog.Len())
+	suite.Equal(zapcore.WarnLevel, suite.obLog.All()[2].Level)
+}
+
+func (suite *KindSuite) TestGinReport() {
+	gin.SetMode(gin.ReleaseMode)
+	for kind, code := range map[string]int{
+		suite.kind.Name: http.StatusTooManyRequests,
+		"":              http.StatusInternalServerError,
+	} {
+		route := gin.New()
+		route.Use(GinPanicErrorHandler("Mock Gin", "error Gin mock"))
+		route.GET("/", func(c *gin.Context) {
+			if kind == "" {
+				panic(New(Kind{}, errors.New("got error")))
+			}
+			panic(New(suite.kind, errors.New("got error")))
+		})
+		w := httptest.NewRecorder()
+		route.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		suite.Equal(code, w.Code)
+	}
+}
+
+func (suite *KindSuite) TestGRPCReport() {
+	var errContent error
+	func() {
+		defer PanicGRPCErrorHandler(&errContent, "MockGRPCHandler", "Test error handler")
+		panic(New(suite.kind, errors.New("quota exceeded")))
+	}()
+	suite.Equal("rpc error: code = ResourceExhausted desc = Test error handler: quota exceeded", errContent.Error())
+
+	New(Kind{}, errors.New("got error")).GRPCReport(&errContent, "prefix")
+	suite.Equal(codes.Unknown, status.Code(errContent))
+}
+
+func (suite *KindSuite) TestNamedTypeSetSystem() {
+	suite.IsType(&ErrNotFound{}, NewErrNotFound(errors.New("got error")).SetSystem("Mock system"))
+	suite.IsType(&ErrDBExecute{}, NewErrDBExecute(errors.New("got error")).SetSystem("Mock system"))
+	suite.IsType(&ErrInvalidArgument{}, NewErrInvalidArgument(errors.New("got error")).SetSystem("Mock system"))
+	suite.IsType(&ErrGRPCConnection{}, NewErrGRPCConnection(errors.New("got error"), nil).SetSystem("Mock system"))
+	suite.Equal(KindNotFound, NewErrNotFound(errors.New("got error")).GetKind())
+}
+
+func (suite *KindSuite) TestRegisterKind() {
+	suite.NoError(RegisterKind(suite.kind))
+	defer func() {
+		kindsMutex.Lock()
+		defer kindsMutex.Unlock()
+		delete(kinds, suite.kind.Name)
+	}()
+	suite.ErrorIs(RegisterKind(suite.kind), ErrKindRegistered)
+	suite.ErrorIs(RegisterKind(KindNotFound), ErrKindRegistered)
+	suite.ErrorIs(RegisterKind(Kind{}), ErrKindNameRequired)
+
+	kind, exist := LookupKind("errQuotaExceeded")
+	suite.True(exist)
+	suite.Equal(suite.kind, kind)
+	_, exist = LookupKind("errUnknown")
+	suite.False(exist)
+
+	result := Kinds()
+	suite.Len(result, 22)
+	suite.Equal(ErrProcessAuthenticate, result[0].Name)
+}
+
+func TestKindSuite(t *testing.T) {
+	suite.Run(t, new(KindSuite))
+}
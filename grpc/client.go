@@ -44,16 +44,20 @@ type IClientConn interface {
 }
 
 // CreateClient method
-func CreateClient(domain string, option config.GRPC) (IClientConn, error) {
-	options := []grpc.DialOption{
+// dials with round robin load balancing, clientOptions add interceptors, retry policy and per-RPC credentials
+func CreateClient(domain string, option config.GRPC, clientOptions ...ClientOption) (IClientConn, error) {
+	c := &client{}
+	for _, clientOption := range clientOptions {
+		clientOption.Apply(c)
+	}
+	options := append([]grpc.DialOption{
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                option.KeepAliveTime,
 			Timeout:             option.KeepAliveTimeout,
 			PermitWithoutStream: option.KeepAlivePermitWithoutStream,
 		}),
-		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`),
-	}
-	if option.NoTLS {
+	}, c.dialOptions(!option.Insecure())...)
+	if option.Insecure() {
 		options = append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 	if option.SkipTLS {
@@ -103,6 +107,6 @@ func CreateClient(domain string, option config.GRPC) (IClientConn, error) {
 
 	return Dial(
 		domain,
-		options...,
+		append(options, c.options...)...,
 	)
 }
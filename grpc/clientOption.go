@@ -0,0 +1,283 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/justdomepaul/toolbox/definition"
+	"github.com/justdomepaul/toolbox/jwt"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ClientOption interface
+type ClientOption interface {
+	Apply(*client)
+}
+
+type client struct {
+	logger  *zap.Logger
+	metrics bool
+	timeout time.Duration
+	retry   *RetryPolicy
+	token   func(ctx context.Context) (string, error)
+	options []grpc.DialOption
+}
+
+// WithClientLogger method
+// logs every call by grpc_zap
+func WithClientLogger(logger *zap.Logger) ClientOption {
+	return withClientLogger{logger: logger}
+}
+
+type withClientLogger struct {
+	logger *zap.Logger
+}
+
+// Apply method
+func (w withClientLogger) Apply(c *client) {
+	c.logger = w.logger
+}
+
+// WithClientMetrics method
+// Prometheus client metrics of grpc_prometheus, e.g. grpc_client_handled_total
+func WithClientMetrics() ClientOption {
+	return withClientMetrics{}
+}
+
+type withClientMetrics struct{}
+
+// Apply method
+func (w withClientMetrics) Apply(c *client) {
+	c.metrics = true
+}
+
+// WithDefaultTimeout method
+// deadline of unary call when context has no deadline
+func WithDefaultTimeout(timeout time.Duration) ClientOption {
+	return withDefaultTimeout{timeout: timeout}
+}
+
+type withDefaultTimeout struct {
+	timeout time.Duration
+}
+
+// Apply method
+func (w withDefaultTimeout) Apply(c *client) {
+	c.timeout = w.timeout
+}
+
+// RetryPolicy type
+// retry policy of service config, zero values use defaults
+type RetryPolicy struct {
+	MaxAttempts          int           // default 4, gRPC limits to 5
+	InitialBackoff       time.Duration // default 100ms
+	MaxBackoff           time.Duration // default 1s
+	BackoffMultiplier    float64       // default 2
+	RetryableStatusCodes []codes.Code  // default Unavailable and ResourceExhausted
+}
+
+// WithRetry method
+// retries calls of all methods with exponential backoff by service config retry policy
+func WithRetry(policy RetryPolicy) ClientOption {
+	return withRetry{policy: policy}
+}
+
+type withRetry struct {
+	policy RetryPolicy
+}
+
+// Apply method
+func (w withRetry) Apply(c *client) {
+	policy := w.policy
+	c.retry = &policy
+}
+
+// WithToken method
+// injects Bearer token of fn into authorization metadata of every call
+func WithToken(fn func(ctx context.Context) (string, error)) ClientOption {
+	return withToken{fn: fn}
+}
+
+type withToken struct {
+	fn func(ctx context.Context) (string, error)
+}
+
+// Apply method
+func (w withToken) Apply(c *client) {
+	c.token = w.fn
+}
+
+// WithTokenSource method
+// Bearer token of access token of source, wrap by oauth2.ReuseTokenSource to cache token
+func WithTokenSource(source oauth2.TokenSource) ClientOption {
+	return withToken{fn: func(ctx context.Context) (string, error) {
+		token, err := source.Token()
+		if err != nil {
+			return "", err
+		}
+		return token.AccessToken, nil
+	}}
+}
+
+// WithJWT method
+// Bearer token signed by signer with claims of every call
+func WithJWT(signer jwt.IJWT, claims func() jwt.IJWTClaims) ClientOption {
+	return withToken{fn: func(ctx context.Context) (string, error) {
+		return signer.GenerateToken(claims())
+	}}
+}
+
+// WithDialOptions method
+// appends raw grpc.DialOption after options of config.GRPC
+func WithDialOptions(options ...grpc.DialOption) ClientOption {
+	return withDialOptions{options: options}
+}
+
+type withDialOptions struct {
+	options []grpc.DialOption
+}
+
+// Apply method
+func (w withDialOptions) Apply(c *client) {
+	c.options = append(c.options, w.options...)
+}
+
+func (c *client) dialOptions(requireTLS bool) []grpc.DialOption {
+	unary := make([]grpc.UnaryClientInterceptor, 0, 3)
+	stream := make([]grpc.StreamClientInterceptor, 0, 2)
+	if c.timeout > 0 {
+		unary = append(unary, timeoutUnaryClientInterceptor(c.timeout))
+	}
+	if c.logger != nil {
+		opts := []grpc_zap.Option{
+			grpc_zap.WithDurationField(func(duration time.Duration) zapcore.Field {
+				return zap.Int64("grpc.time_ns", duration.Nanoseconds())
+			}),
+		}
+		unary = append(unary, grpc_zap.UnaryClientInterceptor(c.logger, opts...))
+		stream = append(stream, grpc_zap.StreamClientInterceptor(c.logger, opts...))
+	}
+	if c.metrics {
+		unary = append(unary, grpc_prometheus.UnaryClientInterceptor)
+		stream = append(stream, grpc_prometheus.StreamClientInterceptor)
+	}
+	options := []grpc.DialOption{
+		grpc.WithDefaultServiceConfig(c.serviceConfig()),
+		grpc.WithChainUnaryInterceptor(unary...),
+		grpc.WithChainStreamInterceptor(stream...),
+	}
+	if c.token != nil {
+		options = append(options, grpc.WithPerRPCCredentials(tokenCredentials{token: c.token, requireTLS: requireTLS}))
+	}
+	return options
+}
+
+func timeoutUnaryClientInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+type serviceConfig struct {
+	LoadBalancingPolicy string         `json:"loadBalancingPolicy"`
+	MethodConfig        []methodConfig `json:"methodConfig,omitempty"`
+}
+
+type methodConfig struct {
+	Name        []struct{}        `json:"name"` // empty name matches all methods
+	RetryPolicy retryPolicyConfig `json:"retryPolicy"`
+}
+
+type retryPolicyConfig struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+func (c *client) serviceConfig() string {
+	cfg := serviceConfig{LoadBalancingPolicy: "round_robin"}
+	if c.retry != nil {
+		cfg.MethodConfig = []methodConfig{{Name: []struct{}{{}}, RetryPolicy: c.retry.config()}}
+	}
+	result, _ := json.Marshal(cfg)
+	return string(result)
+}
+
+func (r RetryPolicy) config() retryPolicyConfig {
+	cfg := retryPolicyConfig{
+		MaxAttempts:       r.MaxAttempts,
+		InitialBackoff:    durationString(r.InitialBackoff, 100*time.Millisecond),
+		MaxBackoff:        durationString(r.MaxBackoff, time.Second),
+		BackoffMultiplier: r.BackoffMultiplier,
+	}
+	if cfg.MaxAttempts <= 1 {
+		cfg.MaxAttempts = 4
+	}
+	if cfg.BackoffMultiplier <= 0 {
+		cfg.BackoffMultiplier = 2
+	}
+	statusCodes := r.RetryableStatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted}
+	}
+	for _, code := range statusCodes {
+		cfg.RetryableStatusCodes = append(cfg.RetryableStatusCodes, statusCodeName(code))
+	}
+	return cfg
+}
+
+// durationString formats duration of service config, e.g. 0.1s
+func durationString(d, defaultValue time.Duration) string {
+	if d <= 0 {
+		d = defaultValue
+	}
+	return fmt.Sprintf("%gs", d.Seconds())
+}
+
+// statusCodeName name of code in service config, e.g. RESOURCE_EXHAUSTED
+func statusCodeName(code codes.Code) string {
+	var b strings.Builder
+	lower := false
+	for _, r := range code.String() {
+		if lower && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		lower = unicode.IsLower(r)
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// tokenCredentials implements credentials.PerRPCCredentials
+type tokenCredentials struct {
+	token      func(ctx context.Context) (string, error)
+	requireTLS bool
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := t.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{definition.AuthorizationKey: definition.AuthorizationType + token}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
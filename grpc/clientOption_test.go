@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	pb "github.com/grpc-ecosystem/go-grpc-middleware/testing/testproto"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/definition"
+	"github.com/justdomepaul/toolbox/jwt"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"sync"
+	"time"
+)
+
+type testFlakyService struct {
+	pb.UnimplementedTestServiceServer
+	mu            sync.Mutex
+	failures      int
+	attempts      int
+	authorization []string
+}
+
+func (t *testFlakyService) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingResponse, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.attempts++
+	md, _ := metadata.FromIncomingContext(ctx)
+	t.authorization = md.Get(definition.AuthorizationKey)
+	if t.failures > 0 {
+		t.failures--
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	if req.GetValue() == "slow" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &pb.PingResponse{Value: req.GetValue()}, nil
+}
+
+// dial serves service on bufconn, returns client created by CreateClient with options
+func (suite *ClientSuite) dial(service pb.TestServiceServer, options ...ClientOption) pb.TestServiceClient {
+	server := grpc.NewServer()
+	pb.RegisterTestServiceServer(server, service)
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	conn, err := CreateClient("bufnet", config.GRPC{NoTLS: true}, append(options, WithDialOptions(
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+	))...)
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() {
+		_ = conn.Close()
+		server.Stop()
+	})
+	return pb.NewTestServiceClient(conn.(*grpc.ClientConn))
+}
+
+func (suite *ClientSuite) TestWithRetry() {
+	service := &testFlakyService{failures: 2}
+	client := suite.dial(service, WithRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+	resp, err := client.Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.NoError(err)
+	suite.Equal("ping", resp.GetValue())
+	suite.Equal(3, service.attempts)
+
+	service.failures, service.attempts = 3, 0
+	_, err = client.Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.Equal(codes.Unavailable, status.Code(err))
+	suite.Equal(3, service.attempts)
+}
+
+func (suite *ClientSuite) TestWithoutRetry() {
+	service := &testFlakyService{failures: 1}
+	_, err := suite.dial(service).Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.Equal(codes.Unavailable, status.Code(err))
+	suite.Equal(1, service.attempts)
+}
+
+func (suite *ClientSuite) TestWithDefaultTimeout() {
+	client := suite.dial(&testFlakyService{}, WithDefaultTimeout(10*time.Millisecond))
+	_, err := client.Ping(context.Background(), &pb.PingRequest{Value: "slow"})
+	suite.Equal(codes.DeadlineExceeded, status.Code(err))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = client.Ping(ctx, &pb.PingRequest{Value: "ping"})
+	suite.NoError(err)
+}
+
+func (suite *ClientSuite) TestWithClientLoggerAndMetrics() {
+	core, logs := observer.New(zapcore.DebugLevel)
+	client := suite.dial(&testFlakyService{}, WithClientLogger(zap.New(core)), WithClientMetrics())
+	_, err := client.Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.NoError(err)
+	suite.Equal(1, logs.FilterField(zap.String("grpc.method", "Ping")).Len())
+}
+
+func (suite *ClientSuite) TestWithJWT() {
+	signer, err := jwt.NewHS256JWT("secret")
+	suite.Require().NoError(err)
+	service := &testFlakyService{}
+	client := suite.dial(service, WithJWT(signer, func() jwt.IJWTClaims {
+		return jwt.NewCommon(jwt.NewClaimsBuilder().ExpiresAfter(time.Minute).Build(), jwt.WithClientID("client"))
+	}))
+	_, err = client.Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.NoError(err)
+	suite.Require().Len(service.authorization, 1)
+	token := service.authorization[0][len(definition.AuthorizationType):]
+	suite.NoError(signer.Validate(token))
+}
+
+func (suite *ClientSuite) TestWithTokenSource() {
+	service := &testFlakyService{}
+	client := suite.dial(service, WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})))
+	_, err := client.Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.NoError(err)
+	suite.Equal([]string{"Bearer token"}, service.authorization)
+
+	client = suite.dial(service, WithToken(func(ctx context.Context) (string, error) {
+		return "", errors.New("token error")
+	}))
+	_, err = client.Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.ErrorContains(err, "token error")
+}
+
+func (suite *ClientSuite) TestServiceConfig() {
+	suite.Equal(`{"loadBalancingPolicy":"round_robin"}`, (&client{}).serviceConfig())
+	suite.Equal(
+		`{"loadBalancingPolicy":"round_robin","methodConfig":[{"name":[{}],"retryPolicy":{"maxAttempts":4,"initialBackoff":"0.1s","maxBackoff":"1s","backoffMultiplier":2,"retryableStatusCodes":["UNAVAILABLE","RESOURCE_EXHAUSTED"]}}]}`,
+		(&client{retry: &RetryPolicy{}}).serviceConfig(),
+	)
+	suite.Equal("DEADLINE_EXCEEDED", statusCodeName(codes.DeadlineExceeded))
+	suite.Equal("OK", statusCodeName(codes.OK))
+	suite.True(tokenCredentials{requireTLS: true}.RequireTransportSecurity())
+}
@@ -0,0 +1,206 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/shutdown"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/connectivity"
+	"sync"
+)
+
+var (
+	// ErrPoolClosed session requested after ClientPool closed
+	ErrPoolClosed = errors.New("gRPC client pool closed")
+	// ErrPoolTargetRequired GetSession called without WithPoolTarget
+	ErrPoolTargetRequired = errors.New("gRPC client pool target required")
+)
+
+// PoolOption interface
+type PoolOption interface {
+	Apply(*ClientPool)
+}
+
+// WithPoolTarget method
+// target of GetSession
+func WithPoolTarget(target string) PoolOption {
+	return withPoolTarget{target: target}
+}
+
+type withPoolTarget struct {
+	target string
+}
+
+// Apply method
+func (w withPoolTarget) Apply(p *ClientPool) {
+	p.target = w.target
+}
+
+// WithPoolSize method
+// connections per target used by round robin, default 1
+func WithPoolSize(size int) PoolOption {
+	return withPoolSize{size: size}
+}
+
+type withPoolSize struct {
+	size int
+}
+
+// Apply method
+func (w withPoolSize) Apply(p *ClientPool) {
+	p.size = w.size
+}
+
+// WithPoolClientOptions method
+// options of CreateClient of every connection
+func WithPoolClientOptions(options ...ClientOption) PoolOption {
+	return withPoolClientOptions{options: options}
+}
+
+type withPoolClientOptions struct {
+	options []ClientOption
+}
+
+// Apply method
+func (w withPoolClientOptions) Apply(p *ClientPool) {
+	p.clientOptions = append(p.clientOptions, w.options...)
+}
+
+// ClientPool type
+// implements IService, dials connections of target by CreateClient lazily and reuses them,
+// connection is redialed when shut down and reconnected immediately when ready connection failed
+type ClientPool struct {
+	option        config.GRPC
+	target        string
+	size          int
+	clientOptions []ClientOption
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	closed  bool
+	targets map[string]*poolTarget
+}
+
+type poolTarget struct {
+	conns []IClientConn
+	next  int
+}
+
+// NewClientPool method
+func NewClientPool(option config.GRPC, options ...PoolOption) *ClientPool {
+	p := &ClientPool{
+		option:  option,
+		size:    1,
+		targets: map[string]*poolTarget{},
+	}
+	for _, o := range options {
+		o.Apply(p)
+	}
+	if p.size <= 0 {
+		p.size = 1
+	}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	return p
+}
+
+// GetSession method
+// connection of WithPoolTarget target
+func (p *ClientPool) GetSession() (IClientConn, error) {
+	if p.target == "" {
+		return nil, ErrPoolTargetRequired
+	}
+	return p.GetTargetSession(p.target)
+}
+
+// GetTargetSession method
+// connection of target by round robin, dialed when absent or shut down
+func (p *ClientPool) GetTargetSession(target string) (IClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+	t, exist := p.targets[target]
+	if !exist {
+		t = &poolTarget{conns: make([]IClientConn, p.size)}
+		p.targets[target] = t
+	}
+	i := t.next % p.size
+	t.next = i + 1
+	if conn := t.conns[i]; conn != nil && conn.GetState() != connectivity.Shutdown {
+		return conn, nil
+	}
+	return p.dial(target, t, i)
+}
+
+// dial replaces i-th connection of target, p.mu must be held
+func (p *ClientPool) dial(target string, t *poolTarget, i int) (IClientConn, error) {
+	conn, err := CreateClient(target, p.option, p.clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	t.conns[i] = conn
+	go p.watch(target, t, i, conn)
+	return conn, nil
+}
+
+// watch reconnects conn by state change until pool closed or conn replaced
+func (p *ClientPool) watch(target string, t *poolTarget, i int, conn IClientConn) {
+	previous := connectivity.Idle
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.TransientFailure:
+			// later failures are retried by backoff of connection
+			if previous == connectivity.Ready {
+				conn.ResetConnectBackoff()
+			}
+		case connectivity.Shutdown:
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if !p.closed && t.conns[i] == conn {
+				if _, err := p.dial(target, t, i); err != nil {
+					t.conns[i] = nil
+					zapTool.Logger.Warn("redial gRPC client", zap.String("target", target), zap.Error(err))
+				}
+			}
+			return
+		}
+		if !conn.WaitForStateChange(p.ctx, state) {
+			return
+		}
+		previous = state
+	}
+}
+
+// Close method
+// closes all connections, GetSession returns ErrPoolClosed afterwards
+func (p *ClientPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	p.cancel()
+	errs := make([]error, 0)
+	for _, t := range p.targets {
+		for _, conn := range t.conns {
+			if conn != nil && conn.GetState() != connectivity.Shutdown {
+				errs = append(errs, conn.Close())
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ShutdownHook method
+// closes pool by shutdown hook of priority, e.g. after servers stopped
+func (p *ClientPool) ShutdownHook(priority int) shutdown.Option {
+	return shutdown.WithHook("gRPC client pool", priority, func(ctx context.Context) error {
+		return p.Close()
+	})
+}
@@ -0,0 +1,145 @@
+package grpc
+
+import (
+	"context"
+	pb "github.com/grpc-ecosystem/go-grpc-middleware/testing/testproto"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/shutdown"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type PoolSuite struct {
+	suite.Suite
+	dialed  *atomic.Int32
+	options PoolOption
+	stubs   *gostub.Stubs
+	server  *grpc.Server
+}
+
+func (suite *PoolSuite) SetupTest() {
+	server, listener, dialed := grpc.NewServer(), bufconn.Listen(1024*1024), &atomic.Int32{}
+	pb.RegisterTestServiceServer(server, &testPingService{})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	suite.server, suite.dialed = server, dialed
+	suite.options = WithPoolClientOptions(WithDialOptions(
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+	))
+	suite.stubs = gostub.Stub(&Dial, func(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+		dialed.Add(1)
+		return grpc.Dial(target, opts...)
+	})
+}
+
+func (suite *PoolSuite) TearDownTest() {
+	suite.stubs.Reset()
+	suite.server.Stop()
+}
+
+func (suite *PoolSuite) TestGetSession() {
+	pool := NewClientPool(config.GRPC{NoTLS: true}, WithPoolTarget("bufnet"), suite.options)
+	defer pool.Close()
+	suite.Implements((*IService)(nil), pool)
+	suite.Zero(suite.dialed.Load())
+
+	session, err := pool.GetSession()
+	suite.NoError(err)
+	_, err = pb.NewTestServiceClient(session.(*grpc.ClientConn)).Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.NoError(err)
+	reused, err := pool.GetSession()
+	suite.NoError(err)
+	suite.Same(session, reused)
+	suite.Equal(int32(1), suite.dialed.Load())
+
+	other, err := pool.GetTargetSession("other")
+	suite.NoError(err)
+	suite.NotSame(session, other)
+	suite.Equal("other", other.Target())
+	suite.Equal(int32(2), suite.dialed.Load())
+}
+
+func (suite *PoolSuite) TestGetSessionTargetRequired() {
+	_, err := NewClientPool(config.GRPC{NoTLS: true}).GetSession()
+	suite.ErrorIs(err, ErrPoolTargetRequired)
+}
+
+func (suite *PoolSuite) TestGetSessionError() {
+	pool := NewClientPool(config.GRPC{TLS: true}, WithPoolTarget("bufnet"))
+	_, err := pool.GetSession()
+	suite.EqualError(err, "gRPC TLS Pem Cert Data Not Found")
+}
+
+func (suite *PoolSuite) TestWithPoolSize() {
+	pool := NewClientPool(config.GRPC{NoTLS: true}, WithPoolTarget("bufnet"), WithPoolSize(2), suite.options)
+	defer pool.Close()
+	sessions := make([]IClientConn, 0, 3)
+	for i := 0; i < 3; i++ {
+		session, err := pool.GetSession()
+		suite.NoError(err)
+		sessions = append(sessions, session)
+	}
+	suite.NotSame(sessions[0], sessions[1])
+	suite.Same(sessions[0], sessions[2])
+	suite.Equal(int32(2), suite.dialed.Load())
+}
+
+func (suite *PoolSuite) TestRedial() {
+	pool := NewClientPool(config.GRPC{NoTLS: true}, WithPoolTarget("bufnet"), suite.options)
+	defer pool.Close()
+	session, err := pool.GetSession()
+	suite.NoError(err)
+	suite.NoError(session.Close())
+
+	// redialed by watcher without GetSession
+	suite.Eventually(func() bool { return suite.dialed.Load() == 2 }, time.Second, time.Millisecond)
+	redialed, err := pool.GetSession()
+	suite.NoError(err)
+	suite.NotSame(session, redialed)
+	suite.NotEqual(connectivity.Shutdown, redialed.GetState())
+	suite.Equal(int32(2), suite.dialed.Load())
+}
+
+func (suite *PoolSuite) TestClose() {
+	pool := NewClientPool(config.GRPC{NoTLS: true}, WithPoolTarget("bufnet"), suite.options)
+	session, err := pool.GetSession()
+	suite.NoError(err)
+	suite.NoError(pool.Close())
+	suite.Equal(connectivity.Shutdown, session.GetState())
+	_, err = pool.GetSession()
+	suite.ErrorIs(err, ErrPoolClosed)
+	suite.NoError(pool.Close())
+	suite.Equal(int32(1), suite.dialed.Load())
+}
+
+func (suite *PoolSuite) TestShutdownHook() {
+	pool := NewClientPool(config.GRPC{NoTLS: true}, WithPoolTarget("bufnet"), suite.options)
+	session, err := pool.GetSession()
+	suite.NoError(err)
+	quit, done := make(chan os.Signal, 1), make(chan error, 1)
+	s := shutdown.NewShutdown(shutdown.WithQuit(quit), shutdown.WithDone(done), pool.ShutdownHook(100))
+	go s.Shutdown()
+	quit <- syscall.SIGTERM
+	suite.NoError(<-done)
+
+	suite.Equal(connectivity.Shutdown, session.GetState())
+	suite.Len(s.Results(), 1)
+	suite.Equal("gRPC client pool", s.Results()[0].Name)
+	suite.Equal(shutdown.HookSucceeded, s.Results()[0].Status)
+}
+
+func TestPoolSuite(t *testing.T) {
+	suite.Run(t, new(PoolSuite))
+}
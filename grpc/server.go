@@ -1,52 +1,136 @@
 package grpc
 
 import (
+	"crypto/tls"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/justdomepaul/toolbox/config"
 	"github.com/justdomepaul/toolbox/interceptor/authenticate"
+	"github.com/justdomepaul/toolbox/interceptor/identity"
+	"github.com/justdomepaul/toolbox/interceptor/logging"
+	"github.com/justdomepaul/toolbox/interceptor/recovery"
 	"github.com/justdomepaul/toolbox/services"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
+	channelz "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/alts"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 	"time"
 )
 
-func CreateServer(logger *zap.Logger, grpcOption config.GRPC, authenticateService services.IAuthenticate) *grpc.Server {
+var (
+	// RecoverySystem system of errors reported by recovery interceptors
+	RecoverySystem = "gRPC"
+)
+
+// CreateServer method
+// logs when server TLS of config.GRPC cannot be loaded and every handshake fails with the error,
+// see CreateServerWithError
+func CreateServer(logger *zap.Logger, grpcOption config.GRPC, authenticateService services.IAuthenticate, serverOptions ...ServerOption) *grpc.Server {
+	result, err := CreateServerWithError(logger, grpcOption, authenticateService, serverOptions...)
+	if err == nil {
+		return result
+	}
+	logger.Error("load gRPC server TLS", zap.Error(err))
+	result, _ = CreateServerWithError(logger, grpcOption, authenticateService, append(serverOptions, WithTLSConfig(&tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return nil, err
+		},
+	}))...)
+	return result
+}
+
+// CreateServerWithError method
+// panics of handlers and every interceptor are recovered into status error by the outermost interceptor and counted by grpc_server_panics_total,
+// server TLS of config.GRPC is used when WithCredentials not set, identity of mTLS client certificate is stored into context
+func CreateServerWithError(logger *zap.Logger, grpcOption config.GRPC, authenticateService services.IAuthenticate, serverOptions ...ServerOption) (*grpc.Server, error) {
+	s := &server{
+		unary:  map[Position][]grpc.UnaryServerInterceptor{},
+		stream: map[Position][]grpc.StreamServerInterceptor{},
+	}
+	for _, option := range serverOptions {
+		option.Apply(s)
+	}
 	opts := []grpc_zap.Option{
 		grpc_zap.WithDurationField(func(duration time.Duration) zapcore.Field {
 			return zap.Int64("grpc.time_ns", duration.Nanoseconds())
 		}),
 	}
-	options := []grpc.ServerOption{
-		grpc.ChainUnaryInterceptor(
+	unary := concat(
+		[]grpc.UnaryServerInterceptor{recovery.UnaryServerInterceptor(RecoverySystem)},
+		s.unary[PositionFirst],
+		[]grpc.UnaryServerInterceptor{
 			grpc_ctxtags.UnaryServerInterceptor(),
+			logging.UnaryServerInterceptor(logger),
 			grpc_zap.UnaryServerInterceptor(logger, opts...),
 			grpc_prometheus.UnaryServerInterceptor,
-			authenticate.UnaryServerInterceptor(authenticateService),
-		),
-		grpc.ChainStreamInterceptor(
+			identity.UnaryServerInterceptor(),
+		},
+		s.unary[PositionBeforeAuthenticate],
+		[]grpc.UnaryServerInterceptor{authenticate.UnaryServerInterceptor(authenticateService)},
+		s.unary[PositionLast],
+	)
+	stream := concat(
+		[]grpc.StreamServerInterceptor{recovery.StreamServerInterceptor(RecoverySystem)},
+		s.stream[PositionFirst],
+		[]grpc.StreamServerInterceptor{
 			grpc_ctxtags.StreamServerInterceptor(),
+			logging.StreamServerInterceptor(logger),
 			grpc_zap.StreamServerInterceptor(logger, opts...),
 			grpc_prometheus.StreamServerInterceptor,
-			authenticate.StreamServerInterceptor(authenticateService),
-		),
+			identity.StreamServerInterceptor(),
+		},
+		s.stream[PositionBeforeAuthenticate],
+		[]grpc.StreamServerInterceptor{authenticate.StreamServerInterceptor(authenticateService)},
+		s.stream[PositionLast],
+	)
+	options := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
-			Timeout: grpcOption.KeepAliveTimeout,
+			Timeout:               grpcOption.KeepAliveTimeout,
+			MaxConnectionAge:      s.maxAge,
+			MaxConnectionAgeGrace: s.maxGrace,
+			MaxConnectionIdle:     s.maxIdle,
 		}),
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			MinTime:             grpcOption.KeepAliveTime,
 			PermitWithoutStream: grpcOption.KeepAlivePermitWithoutStream,
 		}),
 	}
-	if grpcOption.ALTS {
+	switch {
+	case s.creds != nil:
+		options = append(options, grpc.Creds(s.creds))
+	case grpcOption.ServerTLS():
+		tlsConfig, err := NewServerTLSConfig(grpcOption)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	case grpcOption.ALTS:
 		options = append(options, grpc.Creds(alts.NewServerCreds(alts.DefaultServerOptions())))
 	}
 
-	return grpc.NewServer(
-		options...,
+	result := grpc.NewServer(
+		append(options, s.options...)...,
 	)
+	if s.reflection {
+		reflection.Register(result)
+	}
+	if s.channelz {
+		channelz.RegisterChannelzServiceToServer(result)
+	}
+	return result, nil
+}
+
+func concat[T any](groups ...[]T) []T {
+	result := make([]T, 0)
+	for _, group := range groups {
+		result = append(result, group...)
+	}
+	return result
 }
@@ -0,0 +1,190 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"time"
+)
+
+// Position type
+// position of custom interceptors in CreateServer chain
+type Position int
+
+const (
+	// PositionFirst after recovery interceptor, before ctxtags, logging, zap and prometheus interceptors
+	PositionFirst Position = iota
+	// PositionBeforeAuthenticate after identity interceptor, before authenticate interceptor
+	PositionBeforeAuthenticate
+	// PositionLast after authenticate interceptor, claims of authorization are in context
+	PositionLast
+)
+
+// ServerOption interface
+type ServerOption interface {
+	Apply(*server)
+}
+
+type server struct {
+	unary      map[Position][]grpc.UnaryServerInterceptor
+	stream     map[Position][]grpc.StreamServerInterceptor
+	creds      credentials.TransportCredentials
+	options    []grpc.ServerOption
+	maxAge     time.Duration
+	maxGrace   time.Duration
+	maxIdle    time.Duration
+	reflection bool
+	channelz   bool
+}
+
+// WithUnaryInterceptors method
+// appends interceptors at position, interceptors of same position are chained by order of options
+func WithUnaryInterceptors(position Position, interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return withUnaryInterceptors{position: position, interceptors: interceptors}
+}
+
+type withUnaryInterceptors struct {
+	position     Position
+	interceptors []grpc.UnaryServerInterceptor
+}
+
+// Apply method
+func (w withUnaryInterceptors) Apply(s *server) {
+	s.unary[w.position] = append(s.unary[w.position], w.interceptors...)
+}
+
+// WithStreamInterceptors method
+func WithStreamInterceptors(position Position, interceptors ...grpc.StreamServerInterceptor) ServerOption {
+	return withStreamInterceptors{position: position, interceptors: interceptors}
+}
+
+type withStreamInterceptors struct {
+	position     Position
+	interceptors []grpc.StreamServerInterceptor
+}
+
+// Apply method
+func (w withStreamInterceptors) Apply(s *server) {
+	s.stream[w.position] = append(s.stream[w.position], w.interceptors...)
+}
+
+// WithCredentials method
+// transport credentials of server, takes precedence over server TLS and ALTS of config.GRPC
+func WithCredentials(creds credentials.TransportCredentials) ServerOption {
+	return withCredentials{creds: creds}
+}
+
+type withCredentials struct {
+	creds credentials.TransportCredentials
+}
+
+// Apply method
+func (w withCredentials) Apply(s *server) {
+	s.creds = w.creds
+}
+
+// WithTLSConfig method
+// TLS credentials of server, mTLS when ClientAuth and ClientCAs set
+func WithTLSConfig(config *tls.Config) ServerOption {
+	return withCredentials{creds: credentials.NewTLS(config)}
+}
+
+// WithMaxMessageSize method
+// max received and sent message size in bytes, zero keeps gRPC default
+func WithMaxMessageSize(recv, send int) ServerOption {
+	return withMaxMessageSize{recv: recv, send: send}
+}
+
+type withMaxMessageSize struct {
+	recv int
+	send int
+}
+
+// Apply method
+func (w withMaxMessageSize) Apply(s *server) {
+	if w.recv > 0 {
+		s.options = append(s.options, grpc.MaxRecvMsgSize(w.recv))
+	}
+	if w.send > 0 {
+		s.options = append(s.options, grpc.MaxSendMsgSize(w.send))
+	}
+}
+
+// WithMaxConcurrentStreams method
+// max concurrent streams of each client connection
+func WithMaxConcurrentStreams(streams uint32) ServerOption {
+	return withServerOptions{options: []grpc.ServerOption{grpc.MaxConcurrentStreams(streams)}}
+}
+
+// WithConnectionAge method
+// connection is closed gracefully after maxAge, pending RPCs are forcibly closed after grace
+func WithConnectionAge(maxAge, grace time.Duration) ServerOption {
+	return withConnectionAge{maxAge: maxAge, grace: grace}
+}
+
+type withConnectionAge struct {
+	maxAge time.Duration
+	grace  time.Duration
+}
+
+// Apply method
+func (w withConnectionAge) Apply(s *server) {
+	s.maxAge = w.maxAge
+	s.maxGrace = w.grace
+}
+
+// WithMaxConnectionIdle method
+// connection without RPCs is closed after idle
+func WithMaxConnectionIdle(idle time.Duration) ServerOption {
+	return withMaxConnectionIdle{idle: idle}
+}
+
+type withMaxConnectionIdle struct {
+	idle time.Duration
+}
+
+// Apply method
+func (w withMaxConnectionIdle) Apply(s *server) {
+	s.maxIdle = w.idle
+}
+
+// WithReflection method
+// registers server reflection service, e.g. for grpcurl
+func WithReflection() ServerOption {
+	return withReflection{}
+}
+
+type withReflection struct{}
+
+// Apply method
+func (w withReflection) Apply(s *server) {
+	s.reflection = true
+}
+
+// WithChannelz method
+// registers channelz service
+func WithChannelz() ServerOption {
+	return withChannelz{}
+}
+
+type withChannelz struct{}
+
+// Apply method
+func (w withChannelz) Apply(s *server) {
+	s.channelz = true
+}
+
+// WithServerOptions method
+// appends raw grpc.ServerOption
+func WithServerOptions(options ...grpc.ServerOption) ServerOption {
+	return withServerOptions{options: options}
+}
+
+type withServerOptions struct {
+	options []grpc.ServerOption
+}
+
+// Apply method
+func (w withServerOptions) Apply(s *server) {
+	s.options = append(s.options, w.options...)
+}
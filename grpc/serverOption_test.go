@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	pb "github.com/grpc-ecosystem/go-grpc-middleware/testing/testproto"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/definition"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
+)
+
+type testPingService struct {
+	pb.UnimplementedTestServiceServer
+}
+
+func (t *testPingService) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingResponse, error) {
+	return &pb.PingResponse{Value: req.GetValue()}, nil
+}
+
+func (t *testPingService) PingList(req *pb.PingRequest, srv pb.TestService_PingListServer) error {
+	return srv.Send(&pb.PingResponse{Value: req.GetValue()})
+}
+
+func (suite *ServerSuite) TestWithInterceptors() {
+	var order []string
+	unary := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			order = append(order, name)
+			if name == "last" {
+				suite.Equal([]byte("client"), ctx.Value(definition.AuthorizationID))
+			}
+			return handler(ctx, req)
+		}
+	}
+	stream := func(name string) grpc.StreamServerInterceptor {
+		return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			order = append(order, name)
+			return handler(srv, ss)
+		}
+	}
+	auth := &testAuthenticate{}
+	auth.On("Authenticate", mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		order = append(order, "authenticate")
+	}).Return(testAuthorization{}, nil)
+	server := CreateServer(zap.NewNop(), config.GRPC{}, auth,
+		WithUnaryInterceptors(PositionLast, unary("last")),
+		WithUnaryInterceptors(PositionBeforeAuthenticate, unary("before authenticate")),
+		WithUnaryInterceptors(PositionFirst, unary("first"), unary("second")),
+		WithStreamInterceptors(PositionLast, stream("stream last")),
+		WithStreamInterceptors(PositionFirst, stream("stream first")),
+	)
+	pb.RegisterTestServiceServer(server, &testPingService{})
+	client := pb.NewTestServiceClient(suite.serve(server))
+
+	resp, err := client.Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.NoError(err)
+	suite.Equal("ping", resp.GetValue())
+	suite.Equal([]string{"first", "second", "before authenticate", "authenticate", "last"}, order)
+
+	order = nil
+	listClient, err := client.PingList(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.NoError(err)
+	_, err = listClient.Recv()
+	suite.NoError(err)
+	suite.Equal([]string{"stream first", "authenticate", "stream last"}, order)
+}
+
+func (suite *ServerSuite) TestWithMaxMessageSize() {
+	auth := &testAuthenticate{}
+	auth.On("Authenticate", mock.Anything, mock.Anything, mock.Anything).Return(testAuthorization{}, nil)
+	server := CreateServer(zap.NewNop(), config.GRPC{ALTS: true}, auth,
+		WithCredentials(insecure.NewCredentials()),
+		WithMaxMessageSize(64, 0),
+		WithMaxConcurrentStreams(10),
+		WithConnectionAge(time.Hour, time.Minute),
+		WithMaxConnectionIdle(time.Hour),
+		WithServerOptions(grpc.ConnectionTimeout(time.Second)),
+	)
+	pb.RegisterTestServiceServer(server, &testPingService{})
+	client := pb.NewTestServiceClient(suite.serve(server))
+
+	_, err := client.Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.NoError(err)
+	_, err = client.Ping(context.Background(), &pb.PingRequest{Value: strings.Repeat("ping", 64)})
+	suite.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func (suite *ServerSuite) TestWithReflectionAndChannelz() {
+	server := CreateServer(zap.NewNop(), config.GRPC{}, &testAuthenticate{}, WithReflection(), WithChannelz())
+	info := server.GetServiceInfo()
+	suite.Contains(info, "grpc.reflection.v1.ServerReflection")
+	suite.Contains(info, "grpc.channelz.v1.Channelz")
+
+	suite.Empty(CreateServer(zap.NewNop(), config.GRPC{}, &testAuthenticate{}).GetServiceInfo())
+}
@@ -2,11 +2,18 @@ package grpc
 
 import (
 	"context"
+	pb "github.com/grpc-ecosystem/go-grpc-middleware/testing/testproto"
 	"github.com/justdomepaul/toolbox/config"
 	"github.com/justdomepaul/toolbox/services"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
 	"reflect"
 	"testing"
 )
@@ -20,14 +27,68 @@ func (t *testAuthenticate) Authenticate(ctx context.Context, tokenFn func() (str
 	return args.Get(0).(services.IAuthorization), args.Error(1)
 }
 
+type testAuthorization struct{}
+
+func (t testAuthorization) GetID() []byte {
+	return []byte("client")
+}
+
+func (t testAuthorization) GetClaim() interface{} {
+	return nil
+}
+
 type ServerSuite struct {
 	suite.Suite
 }
 
+// serve serves server on bufconn, returns connection closed with server by cleanup
+func (suite *ServerSuite) serve(server *grpc.Server) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	conn, err := grpc.DialContext(context.Background(), "",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() {
+		_ = conn.Close()
+		server.Stop()
+	})
+	return conn
+}
+
 func (suite *ServerSuite) TestCreateServer() {
 	suite.Equal("*grpc.Server", reflect.TypeOf(CreateServer(zap.NewExample(), config.GRPC{ALTS: true}, &testAuthenticate{})).String())
 }
 
+func (suite *ServerSuite) TestCreateServerRecovery() {
+	auth := &testAuthenticate{}
+	auth.On("Authenticate", mock.Anything, mock.Anything, "/mwitkow.testproto.TestService/Ping").Panic("got panic")
+	server := CreateServer(zap.NewNop(), config.GRPC{}, auth)
+	pb.RegisterTestServiceServer(server, &pb.UnimplementedTestServiceServer{})
+
+	_, err := pb.NewTestServiceClient(suite.serve(server)).Ping(context.Background(), &pb.PingRequest{})
+	suite.Equal(codes.Unknown, status.Code(err))
+	suite.Equal("/mwitkow.testproto.TestService/Ping: got panic", status.Convert(err).Message())
+}
+
+func (suite *ServerSuite) TestCreateServerRecoveryFirst() {
+	server := CreateServer(zap.NewNop(), config.GRPC{}, &testAuthenticate{}, WithUnaryInterceptors(PositionFirst,
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			panic("got interceptor panic")
+		},
+	))
+	pb.RegisterTestServiceServer(server, &pb.UnimplementedTestServiceServer{})
+
+	_, err := pb.NewTestServiceClient(suite.serve(server)).Ping(context.Background(), &pb.PingRequest{})
+	suite.Equal(codes.Unknown, status.Code(err))
+	suite.Equal("/mwitkow.testproto.TestService/Ping: got interceptor panic", status.Convert(err).Message())
+}
+
 func TestServerSuite(t *testing.T) {
 	suite.Run(t, new(ServerSuite))
 }
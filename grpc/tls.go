@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"github.com/cockroachdb/errors"
+	"github.com/justdomepaul/toolbox/config"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"go.uber.org/zap"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	readFile = os.ReadFile
+	statFile = os.Stat
+	now      = time.Now
+)
+
+// NewServerTLSConfig method
+// tls.Config of config.GRPC server certificate, client certificate is required and verified when client CA set,
+// certificate, key and client CA files are reloaded on handshake after modified
+func NewServerTLSConfig(option config.GRPC) (*tls.Config, error) {
+	if !option.ServerTLS() {
+		return nil, errors.New("gRPC server TLS certificate not found")
+	}
+	r := &certificateReloader{option: option}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	if len(r.files()) == 0 {
+		return r.config, nil
+	}
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.getConfigForClient,
+	}, nil
+}
+
+type certificateReloader struct {
+	option config.GRPC
+
+	mu       sync.Mutex
+	config   *tls.Config
+	modTimes map[string]time.Time
+	checked  time.Time
+}
+
+func (r *certificateReloader) files() []string {
+	files := make([]string, 0, 3)
+	for _, file := range []string{r.option.ServerTLSCertFile, r.option.ServerTLSKeyFile, r.option.ServerTLSClientCAFile} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func (r *certificateReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now().Sub(r.checked) < r.option.ServerTLSReloadInterval {
+		return r.config, nil
+	}
+	r.checked = now()
+	for file, modTime := range r.stat() {
+		if !modTime.Equal(r.modTimes[file]) {
+			// current certificate is kept when modified files invalid, e.g. key not written yet
+			if err := r.load(); err != nil {
+				zapTool.Logger.Warn("reload gRPC server TLS certificate", zap.Error(err))
+			}
+			break
+		}
+	}
+	return r.config, nil
+}
+
+func (r *certificateReloader) stat() map[string]time.Time {
+	modTimes := map[string]time.Time{}
+	for _, file := range r.files() {
+		if info, err := statFile(file); err == nil {
+			modTimes[file] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+func (r *certificateReloader) load() error {
+	modTimes := r.stat()
+	certPEM, err := pemData(r.option.ServerTLSCert, r.option.ServerTLSCertBase64, r.option.ServerTLSCertFile)
+	if err != nil {
+		return err
+	}
+	keyPEM, err := pemData(r.option.ServerTLSKey, r.option.ServerTLSKeyBase64, r.option.ServerTLSKeyFile)
+	if err != nil {
+		return err
+	}
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return errors.Wrap(err, "gRPC server TLS certificate format error")
+	}
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+		// config of GetConfigForClient is used as is, h2 is not added by credentials.NewTLS
+		NextProtos: []string{"h2"},
+	}
+	if r.option.ServerMTLS() {
+		caPEM, err := pemData(r.option.ServerTLSClientCA, r.option.ServerTLSClientCABase64, r.option.ServerTLSClientCAFile)
+		if err != nil {
+			return err
+		}
+		cp := x509.NewCertPool()
+		if !cp.AppendCertsFromPEM(caPEM) {
+			return errors.New("gRPC server TLS client CA format error")
+		}
+		cfg.ClientCAs = cp
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	r.config = cfg
+	r.modTimes = modTimes
+	return nil
+}
+
+// pemData PEM of data, base64 data or file, only one is set
+func pemData(data, base64Data, file string) ([]byte, error) {
+	switch {
+	case data != "":
+		return []byte(data), nil
+	case base64Data != "":
+		return base64.StdEncoding.DecodeString(base64Data)
+	}
+	return readFile(file)
+}
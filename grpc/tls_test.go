@@ -0,0 +1,270 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	pb "github.com/grpc-ecosystem/go-grpc-middleware/testing/testproto"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/interceptor/identity"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCertificate self-signed CA when parent nil, leaf of parent otherwise
+func newTestCertificate(commonName string, parent *testCertificate, uris ...string) testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, raw := range uris {
+		uri, _ := url.Parse(raw)
+		template.URIs = append(template.URIs, uri)
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		panic(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+type TLSSuite struct {
+	suite.Suite
+	ca     testCertificate
+	server testCertificate
+	client testCertificate
+}
+
+func (suite *TLSSuite) SetupSuite() {
+	suite.ca = newTestCertificate("ca", nil)
+	suite.server = newTestCertificate("server", &suite.ca)
+	suite.client = newTestCertificate("client", &suite.ca, "spiffe://example.org/client")
+}
+
+func (suite *TLSSuite) leaf(cfg *tls.Config) string {
+	if cfg.GetConfigForClient != nil {
+		var err error
+		cfg, err = cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+		suite.Require().NoError(err)
+	}
+	cert, err := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+	suite.Require().NoError(err)
+	return cert.Subject.CommonName
+}
+
+func (suite *TLSSuite) TestNewServerTLSConfig() {
+	cfg, err := NewServerTLSConfig(config.GRPC{ServerTLSCert: string(suite.server.certPEM), ServerTLSKey: string(suite.server.keyPEM)})
+	suite.NoError(err)
+	suite.Equal("server", suite.leaf(cfg))
+	suite.Equal(tls.NoClientCert, cfg.ClientAuth)
+
+	cfg, err = NewServerTLSConfig(config.GRPC{
+		ServerTLSCertBase64:     base64.StdEncoding.EncodeToString(suite.server.certPEM),
+		ServerTLSKeyBase64:      base64.StdEncoding.EncodeToString(suite.server.keyPEM),
+		ServerTLSClientCABase64: base64.StdEncoding.EncodeToString(suite.ca.certPEM),
+	})
+	suite.NoError(err)
+	suite.Equal(tls.RequireAndVerifyClientCert, cfg.ClientAuth)
+	suite.NotNil(cfg.ClientCAs)
+}
+
+func (suite *TLSSuite) TestNewServerTLSConfigError() {
+	_, err := NewServerTLSConfig(config.GRPC{})
+	suite.EqualError(err, "gRPC server TLS certificate not found")
+	_, err = NewServerTLSConfig(config.GRPC{ServerTLSCert: "cert", ServerTLSKey: "key"})
+	suite.ErrorContains(err, "gRPC server TLS certificate format error")
+	_, err = NewServerTLSConfig(config.GRPC{ServerTLSCert: string(suite.server.certPEM), ServerTLSKey: string(suite.server.keyPEM), ServerTLSClientCA: "ca"})
+	suite.EqualError(err, "gRPC server TLS client CA format error")
+	_, err = NewServerTLSConfig(config.GRPC{ServerTLSCertFile: filepath.Join(suite.T().TempDir(), "cert.pem"), ServerTLSKey: string(suite.server.keyPEM)})
+	suite.ErrorIs(err, os.ErrNotExist)
+}
+
+func (suite *TLSSuite) TestReload() {
+	dir := suite.T().TempDir()
+	option := config.GRPC{
+		ServerTLSCertFile:     filepath.Join(dir, "cert.pem"),
+		ServerTLSKeyFile:      filepath.Join(dir, "key.pem"),
+		ServerTLSClientCAFile: filepath.Join(dir, "ca.pem"),
+	}
+	write := func(certificate testCertificate, modTime time.Time) {
+		suite.Require().NoError(os.WriteFile(option.ServerTLSCertFile, certificate.certPEM, 0600))
+		suite.Require().NoError(os.WriteFile(option.ServerTLSKeyFile, certificate.keyPEM, 0600))
+		suite.Require().NoError(os.WriteFile(option.ServerTLSClientCAFile, suite.ca.certPEM, 0600))
+		for _, file := range []string{option.ServerTLSCertFile, option.ServerTLSKeyFile} {
+			suite.Require().NoError(os.Chtimes(file, modTime, modTime))
+		}
+	}
+	write(suite.server, time.Now().Add(-time.Minute))
+	cfg, err := NewServerTLSConfig(option)
+	suite.NoError(err)
+	suite.Equal("server", suite.leaf(cfg))
+
+	write(newTestCertificate("renewed", &suite.ca), time.Now())
+	suite.Equal("renewed", suite.leaf(cfg))
+
+	// invalid key keeps current certificate
+	suite.Require().NoError(os.WriteFile(option.ServerTLSKeyFile, []byte("key"), 0600))
+	suite.Require().NoError(os.Chtimes(option.ServerTLSKeyFile, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+	suite.Equal("renewed", suite.leaf(cfg))
+}
+
+func (suite *TLSSuite) TestReloadInterval() {
+	dir := suite.T().TempDir()
+	option := config.GRPC{ServerTLSCertFile: filepath.Join(dir, "cert.pem"), ServerTLSKeyFile: filepath.Join(dir, "key.pem"), ServerTLSReloadInterval: time.Hour}
+	suite.Require().NoError(os.WriteFile(option.ServerTLSCertFile, suite.server.certPEM, 0600))
+	suite.Require().NoError(os.WriteFile(option.ServerTLSKeyFile, suite.server.keyPEM, 0600))
+	cfg, err := NewServerTLSConfig(option)
+	suite.NoError(err)
+	suite.Equal("server", suite.leaf(cfg))
+
+	renewed := newTestCertificate("renewed", &suite.ca)
+	suite.Require().NoError(os.WriteFile(option.ServerTLSCertFile, renewed.certPEM, 0600))
+	suite.Require().NoError(os.WriteFile(option.ServerTLSKeyFile, renewed.keyPEM, 0600))
+	suite.Equal("server", suite.leaf(cfg))
+}
+
+func (suite *TLSSuite) TestCreateServerMTLS() {
+	dir := suite.T().TempDir()
+	files := config.GRPC{
+		ServerTLSCertFile:     filepath.Join(dir, "cert.pem"),
+		ServerTLSKeyFile:      filepath.Join(dir, "key.pem"),
+		ServerTLSClientCAFile: filepath.Join(dir, "ca.pem"),
+	}
+	suite.Require().NoError(os.WriteFile(files.ServerTLSCertFile, suite.server.certPEM, 0600))
+	suite.Require().NoError(os.WriteFile(files.ServerTLSKeyFile, suite.server.keyPEM, 0600))
+	suite.Require().NoError(os.WriteFile(files.ServerTLSClientCAFile, suite.ca.certPEM, 0600))
+
+	for name, option := range map[string]config.GRPC{
+		"data": {
+			ServerTLSCert:     string(suite.server.certPEM),
+			ServerTLSKey:      string(suite.server.keyPEM),
+			ServerTLSClientCA: string(suite.ca.certPEM),
+		},
+		// reloadable certificate is served by GetConfigForClient
+		"file": files,
+	} {
+		var result identity.Identity
+		protocol := ""
+		auth := &testAuthenticate{}
+		auth.On("Authenticate", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			ctx := args.Get(0).(context.Context)
+			result, _ = identity.FromContext(ctx)
+			if p, ok := peer.FromContext(ctx); ok {
+				protocol = p.AuthInfo.(credentials.TLSInfo).State.NegotiatedProtocol
+			}
+		}).Return(testAuthorization{}, nil)
+		server, err := CreateServerWithError(zap.NewNop(), option, auth)
+		suite.Require().NoError(err, name)
+		pb.RegisterTestServiceServer(server, &testPingService{})
+		listener := bufconn.Listen(1024 * 1024)
+		go func() {
+			_ = server.Serve(listener)
+		}()
+
+		roots := x509.NewCertPool()
+		roots.AddCert(suite.ca.cert)
+		dial := func(certificates ...tls.Certificate) error {
+			conn, err := grpc.DialContext(context.Background(), "localhost",
+				grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+					return listener.Dial()
+				}),
+				grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: roots, Certificates: certificates})),
+			)
+			suite.Require().NoError(err)
+			defer conn.Close()
+			_, err = pb.NewTestServiceClient(conn).Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+			return err
+		}
+
+		certificate, err := tls.X509KeyPair(suite.client.certPEM, suite.client.keyPEM)
+		suite.Require().NoError(err)
+		suite.NoError(dial(certificate), name)
+		suite.Equal("spiffe://example.org/client", result.SPIFFEID, name)
+		suite.Equal("client", result.CommonName, name)
+		suite.Equal("h2", protocol, name)
+		suite.Error(dial(), name)
+		server.Stop()
+	}
+}
+
+func (suite *TLSSuite) TestCreateServerError() {
+	_, err := CreateServerWithError(zap.NewNop(), config.GRPC{ServerTLSCert: "cert", ServerTLSKey: "key"}, &testAuthenticate{})
+	suite.Error(err)
+
+	core, logs := observer.New(zapcore.ErrorLevel)
+	server := CreateServer(zap.New(core), config.GRPC{ServerTLSCert: "cert", ServerTLSKey: "key"}, &testAuthenticate{})
+	suite.Require().NotNil(server)
+	suite.Equal(1, logs.FilterMessage("load gRPC server TLS").Len())
+	pb.RegisterTestServiceServer(server, &testPingService{})
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "localhost",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})),
+	)
+	suite.Require().NoError(err)
+	defer conn.Close()
+	_, err = pb.NewTestServiceClient(conn).Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.Equal(codes.Unavailable, status.Code(err))
+}
+
+func TestTLSSuite(t *testing.T) {
+	suite.Run(t, new(TLSSuite))
+}
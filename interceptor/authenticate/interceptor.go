@@ -55,7 +55,7 @@ func StreamServerInterceptor(auth services.IAuthenticate) func(srv interface{},
 }
 
 func authenticate(ctx context.Context, auth services.IAuthenticate, fullMethod string) (services.IAuthorization, error) {
-	result, err := auth.Authenticate(nil, func() (string, error) {
+	result, err := auth.Authenticate(ctx, func() (string, error) {
 		return utils.GetAccessToken(ctx)
 	}, fullMethod)
 	if _, exist := status.FromError(err); err != nil && exist {
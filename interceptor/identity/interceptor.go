@@ -0,0 +1,94 @@
+package identity
+
+import (
+	"context"
+	"crypto/x509"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+type identityKey struct{}
+
+// Identity type
+// identity of verified client certificate of mTLS
+type Identity struct {
+	SPIFFEID    string // URI SAN of spiffe scheme
+	CommonName  string
+	DNSNames    []string
+	Certificate *x509.Certificate
+}
+
+// Name method
+// SPIFFE ID, common name when SPIFFE ID absent
+func (i Identity) Name() string {
+	if i.SPIFFEID != "" {
+		return i.SPIFFEID
+	}
+	return i.CommonName
+}
+
+// FromContext method
+// identity stored by interceptors, e.g. for services.IAuthenticate to authorize by SPIFFE ID or common name
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// FromPeer method
+// identity of leaf certificate of first verified chain of gRPC peer, false when client certificate not verified
+func FromPeer(ctx context.Context) (Identity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Identity{}, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return Identity{}, false
+	}
+	return fromCertificate(info.State.VerifiedChains[0][0]), true
+}
+
+func fromCertificate(certificate *x509.Certificate) Identity {
+	identity := Identity{
+		CommonName:  certificate.Subject.CommonName,
+		DNSNames:    certificate.DNSNames,
+		Certificate: certificate,
+	}
+	for _, uri := range certificate.URIs {
+		if uri.Scheme == "spiffe" {
+			identity.SPIFFEID = uri.String()
+			break
+		}
+	}
+	return identity
+}
+
+// UnaryServerInterceptor method
+// stores identity of verified client certificate into context and identity tag of grpc_ctxtags,
+// context is unchanged without client certificate
+func UnaryServerInterceptor() func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withIdentity(ctx), req)
+	}
+}
+
+// StreamServerInterceptor method
+func StreamServerInterceptor() func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = withIdentity(ss.Context())
+		return handler(srv, wrapped)
+	}
+}
+
+func withIdentity(ctx context.Context) context.Context {
+	identity, ok := FromPeer(ctx)
+	if !ok {
+		return ctx
+	}
+	grpc_ctxtags.Extract(ctx).Set("identity", identity.Name())
+	return context.WithValue(ctx, identityKey{}, identity)
+}
@@ -0,0 +1,97 @@
+package identity
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"net/url"
+	"testing"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (t testServerStream) Context() context.Context {
+	return t.ctx
+}
+
+type InterceptorSuite struct {
+	suite.Suite
+	certificate *x509.Certificate
+	ctx         context.Context
+}
+
+func (suite *InterceptorSuite) SetupTest() {
+	uri, _ := url.Parse("spiffe://example.org/client")
+	suite.certificate = &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "client"},
+		DNSNames: []string{"client.example.org"},
+		URIs:     []*url.URL{{Scheme: "https", Host: "example.org"}, uri},
+	}
+	suite.ctx = grpc_ctxtags.SetInContext(peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{suite.certificate}}}},
+	}), grpc_ctxtags.NewTags())
+}
+
+func (suite *InterceptorSuite) TestFromPeer() {
+	identity, ok := FromPeer(suite.ctx)
+	suite.True(ok)
+	suite.Equal(Identity{
+		SPIFFEID:    "spiffe://example.org/client",
+		CommonName:  "client",
+		DNSNames:    []string{"client.example.org"},
+		Certificate: suite.certificate,
+	}, identity)
+
+	_, ok = FromPeer(context.Background())
+	suite.False(ok)
+	_, ok = FromPeer(peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{suite.certificate},
+	}}}))
+	suite.False(ok)
+}
+
+func (suite *InterceptorSuite) TestName() {
+	suite.Equal("spiffe://example.org/client", Identity{SPIFFEID: "spiffe://example.org/client", CommonName: "client"}.Name())
+	suite.Equal("client", Identity{CommonName: "client"}.Name())
+}
+
+func (suite *InterceptorSuite) TestUnaryServerInterceptor() {
+	_, err := UnaryServerInterceptor()(suite.ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		identity, ok := FromContext(ctx)
+		suite.True(ok)
+		suite.Equal("client", identity.CommonName)
+		return nil, nil
+	})
+	suite.NoError(err)
+	suite.Equal(map[string]interface{}{"identity": "spiffe://example.org/client"}, grpc_ctxtags.Extract(suite.ctx).Values())
+
+	_, err = UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, ok := FromContext(ctx)
+		suite.False(ok)
+		return nil, nil
+	})
+	suite.NoError(err)
+}
+
+func (suite *InterceptorSuite) TestStreamServerInterceptor() {
+	err := StreamServerInterceptor()(nil, testServerStream{ctx: suite.ctx}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		identity, ok := FromContext(stream.Context())
+		suite.True(ok)
+		suite.Equal("spiffe://example.org/client", identity.SPIFFEID)
+		return nil
+	})
+	suite.NoError(err)
+}
+
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorSuite))
+}
@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"context"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/justdomepaul/toolbox/definition"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"go.opentelemetry.io/otel/propagation"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor method
+// stores logger, request ID of x-request-id metadata (generated when absent, sent back as header)
+// and W3C traceparent span context into context, handler logs by zap.FromContext(ctx) are correlated
+func UnaryServerInterceptor(logger *zap.Logger) func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, id := withRequest(ctx, logger)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(definition.RequestIDKey, id))
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor method
+func StreamServerInterceptor(logger *zap.Logger) func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := withRequest(ss.Context(), logger)
+		_ = ss.SetHeader(metadata.Pairs(definition.RequestIDKey, id))
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
+
+func withRequest(ctx context.Context, logger *zap.Logger) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := zapTool.NewRequestID(first(md.Get(definition.RequestIDKey)))
+	// request_id of grpc_zap call log
+	grpc_ctxtags.Extract(ctx).Set("request_id", id)
+	ctx = propagation.TraceContext{}.Extract(ctx, metadataCarrier(md))
+	return zapTool.WithRequestID(zapTool.WithContext(ctx, logger), id), id
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// metadataCarrier adapts incoming metadata to propagation.TextMapCarrier
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	return first(metadata.MD(m).Get(key))
+}
+
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
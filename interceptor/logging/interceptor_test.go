@@ -0,0 +1,118 @@
+package logging
+
+import (
+	"context"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	pb "github.com/grpc-ecosystem/go-grpc-middleware/testing/testproto"
+	"github.com/justdomepaul/toolbox/definition"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"testing"
+)
+
+type testService struct {
+	pb.UnimplementedTestServiceServer
+	tags map[string]interface{}
+}
+
+func (t *testService) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingResponse, error) {
+	t.tags = grpc_ctxtags.Extract(ctx).Values()
+	zapTool.FromContext(ctx).Info("ping")
+	return &pb.PingResponse{}, nil
+}
+
+func (t *testService) PingList(req *pb.PingRequest, srv pb.TestService_PingListServer) error {
+	zapTool.FromContext(srv.Context()).Info("ping list")
+	return srv.Send(&pb.PingResponse{})
+}
+
+// setClientID simulates authenticate interceptor
+func setClientID(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(context.WithValue(ctx, definition.AuthorizationID, []byte("client")), req)
+}
+
+type InterceptorSuite struct {
+	suite.Suite
+	service *testService
+	logs    *observer.ObservedLogs
+	server  *grpc.Server
+	conn    *grpc.ClientConn
+}
+
+func (suite *InterceptorSuite) SetupTest() {
+	core, logs := observer.New(zapcore.DebugLevel)
+	suite.logs = logs
+	suite.service = &testService{}
+	suite.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpc_ctxtags.UnaryServerInterceptor(), UnaryServerInterceptor(zap.New(core)), setClientID),
+		grpc.ChainStreamInterceptor(grpc_ctxtags.StreamServerInterceptor(), StreamServerInterceptor(zap.New(core))),
+	)
+	pb.RegisterTestServiceServer(suite.server, suite.service)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = suite.server.Serve(listener)
+	}()
+	conn, err := grpc.DialContext(context.Background(), "",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	suite.Require().NoError(err)
+	suite.conn = conn
+}
+
+func (suite *InterceptorSuite) TearDownTest() {
+	suite.NoError(suite.conn.Close())
+	suite.server.Stop()
+}
+
+func (suite *InterceptorSuite) TestUnaryServerInterceptor() {
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		definition.RequestIDKey, "request-1",
+		"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	)
+	var header metadata.MD
+	_, err := pb.NewTestServiceClient(suite.conn).Ping(ctx, &pb.PingRequest{}, grpc.Header(&header))
+	suite.NoError(err)
+
+	suite.Equal([]string{"request-1"}, header.Get(definition.RequestIDKey))
+	suite.Equal("request-1", suite.service.tags["request_id"])
+	suite.Equal(1, suite.logs.Len())
+	suite.Equal(map[string]interface{}{
+		"request_id": "request-1",
+		"trace_id":   "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":    "00f067aa0ba902b7",
+		"client_id":  "client",
+	}, suite.logs.All()[0].ContextMap())
+}
+
+func (suite *InterceptorSuite) TestStreamServerInterceptor() {
+	stream, err := pb.NewTestServiceClient(suite.conn).PingList(context.Background(), &pb.PingRequest{})
+	suite.NoError(err)
+	_, err = stream.Recv()
+	suite.NoError(err)
+	_, err = stream.Recv()
+	suite.Equal(io.EOF, err)
+	header, err := stream.Header()
+	suite.NoError(err)
+
+	id := header.Get(definition.RequestIDKey)
+	suite.Len(id, 1)
+	suite.Equal(1, suite.logs.Len())
+	suite.Equal(map[string]interface{}{"request_id": id[0]}, suite.logs.All()[0].ContextMap())
+}
+
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorSuite))
+}
@@ -0,0 +1,71 @@
+package recovery
+
+import (
+	"context"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"strings"
+)
+
+var (
+	// PanicsTotal counts panics recovered by interceptors, registered to prometheus.DefaultRegisterer
+	PanicsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_panics_total",
+		Help: "Total number of panics recovered in gRPC handlers on the server.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+)
+
+func init() {
+	prometheus.MustRegister(PanicsTotal)
+}
+
+// UnaryServerInterceptor method
+// recovers panic of handler by errorhandler.GRPCPanicError semantics, full method is prefix message of report
+func UnaryServerInterceptor(system string) func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(r, "unary", info.FullMethod, system)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor method
+func StreamServerInterceptor(system string) func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(r, streamType(info), info.FullMethod, system)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recovered(r interface{}, grpcType, fullMethod, system string) error {
+	service, method := splitMethodName(fullMethod)
+	PanicsTotal.WithLabelValues(grpcType, service, method).Inc()
+	return errorhandler.GRPCPanicError(r, system, fullMethod)
+}
+
+// streamType labels stream like grpc_prometheus
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && !info.IsServerStream:
+		return "client_stream"
+	case !info.IsClientStream && info.IsServerStream:
+		return "server_stream"
+	}
+	return "bidi_stream"
+}
+
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", "unknown"
+}
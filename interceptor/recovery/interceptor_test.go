@@ -0,0 +1,105 @@
+package recovery
+
+import (
+	"context"
+	"errors"
+	pb "github.com/grpc-ecosystem/go-grpc-middleware/testing/testproto"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/utils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"testing"
+)
+
+type testService struct {
+	pb.UnimplementedTestServiceServer
+}
+
+func (t *testService) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingResponse, error) {
+	switch req.GetValue() {
+	case "uuid":
+		utils.ParseUUID(req.GetValue())
+	case "report":
+		panic(errorhandler.NewErrDBRowNotFound(errors.New("row not found")))
+	}
+	return &pb.PingResponse{Value: req.GetValue()}, nil
+}
+
+func (t *testService) PingList(req *pb.PingRequest, srv pb.TestService_PingListServer) error {
+	panic("ping list")
+}
+
+type InterceptorSuite struct {
+	suite.Suite
+	server *grpc.Server
+	conn   *grpc.ClientConn
+}
+
+func (suite *InterceptorSuite) SetupTest() {
+	PanicsTotal.Reset()
+	suite.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor("Mock system")),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor("Mock system")),
+	)
+	pb.RegisterTestServiceServer(suite.server, &testService{})
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = suite.server.Serve(listener)
+	}()
+	conn, err := grpc.DialContext(context.Background(), "",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	suite.Require().NoError(err)
+	suite.conn = conn
+}
+
+func (suite *InterceptorSuite) TearDownTest() {
+	suite.NoError(suite.conn.Close())
+	suite.server.Stop()
+}
+
+func (suite *InterceptorSuite) TestUnaryServerInterceptor() {
+	client := pb.NewTestServiceClient(suite.conn)
+	resp, err := client.Ping(context.Background(), &pb.PingRequest{Value: "ping"})
+	suite.NoError(err)
+	suite.Equal("ping", resp.GetValue())
+
+	_, err = client.Ping(context.Background(), &pb.PingRequest{Value: "uuid"})
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.Ping(context.Background(), &pb.PingRequest{Value: "report"})
+	suite.Equal(codes.NotFound, status.Code(err))
+	suite.Equal("/mwitkow.testproto.TestService/Ping: row not found", status.Convert(err).Message())
+
+	suite.Equal(float64(2), testutil.ToFloat64(PanicsTotal.WithLabelValues("unary", "mwitkow.testproto.TestService", "Ping")))
+}
+
+func (suite *InterceptorSuite) TestStreamServerInterceptor() {
+	stream, err := pb.NewTestServiceClient(suite.conn).PingList(context.Background(), &pb.PingRequest{})
+	suite.NoError(err)
+	_, err = stream.Recv()
+	suite.Equal(codes.Unknown, status.Code(err))
+	suite.Equal("/mwitkow.testproto.TestService/PingList: ping list", status.Convert(err).Message())
+
+	suite.Equal(float64(1), testutil.ToFloat64(PanicsTotal.WithLabelValues("server_stream", "mwitkow.testproto.TestService", "PingList")))
+}
+
+func (suite *InterceptorSuite) TestSplitMethodName() {
+	service, method := splitMethodName("invalid")
+	suite.Equal("unknown", service)
+	suite.Equal("unknown", method)
+}
+
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorSuite))
+}
@@ -0,0 +1,23 @@
+package pagination
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/restful"
+)
+
+// Bind method
+// binds row, page and cursor from query string, returns *errorhandler.ErrInvalidArgument when failed
+func Bind(c *gin.Context) (Request, error) {
+	request, err := restful.Bind[Request](c)
+	if err != nil {
+		return request, err
+	}
+	if _, err := request.DecodeCursor(); err != nil {
+		return request, errorhandler.NewErrInvalidArgument(err, errorhandler.FieldViolation{
+			Field:       "cursor",
+			Description: "invalid cursor",
+		})
+	}
+	return request, nil
+}
@@ -0,0 +1,49 @@
+package pagination
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type GinSuite struct {
+	suite.Suite
+}
+
+func (suite *GinSuite) context(target string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c
+}
+
+func (suite *GinSuite) TestBindDefault() {
+	request, err := Bind(suite.context("/users"))
+	suite.NoError(err)
+	suite.Equal(Request{Row: 20, Page: 1}, request)
+}
+
+func (suite *GinSuite) TestBind() {
+	cursor := Cursor{Key: "a"}.Encode()
+	request, err := Bind(suite.context("/users?row=5&page=3&cursor=" + cursor))
+	suite.NoError(err)
+	suite.Equal(Request{Row: 5, Page: 3, Cursor: cursor}, request)
+}
+
+func (suite *GinSuite) TestBindValidationError() {
+	_, err := Bind(suite.context("/users?row=5000&page=0"))
+	suite.Error(err)
+	suite.Len(err.(*errorhandler.ErrInvalidArgument).GetFieldViolations(), 2)
+}
+
+func (suite *GinSuite) TestBindCursorError() {
+	_, err := Bind(suite.context("/users?cursor=0OIl"))
+	suite.ErrorIs(err.(*errorhandler.ErrInvalidArgument).GetError(), errorhandler.ErrInvalidArguments)
+	suite.Equal("cursor", err.(*errorhandler.ErrInvalidArgument).GetFieldViolations()[0].Field)
+}
+
+func TestGinSuite(t *testing.T) {
+	suite.Run(t, new(GinSuite))
+}
@@ -0,0 +1,42 @@
+package pagination
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OffsetFindOptions method
+// combine with SetSort to get stable pages
+func OffsetFindOptions(offset Offset) *options.FindOptions {
+	return options.Find().SetSkip(offset.Skip()).SetLimit(offset.Limit())
+}
+
+// Mongo method
+// returns filter and find options to fetch row+1 documents after the cursor ordered by Keyset column,
+// filter can be nil, error when cursor key cannot be decoded by Keyset.Decode
+func (k Keyset) Mongo(filter interface{}, cursor Cursor, row int64) (interface{}, *options.FindOptions, error) {
+	direction := 1
+	if !k.ascending(cursor) {
+		direction = -1
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: k.Column, Value: direction}}).SetLimit(row + 1)
+	if cursor.IsZero() {
+		if filter == nil {
+			filter = bson.D{}
+		}
+		return filter, findOptions, nil
+	}
+	key, err := k.value(cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	operator := "$gt"
+	if !k.ascending(cursor) {
+		operator = "$lt"
+	}
+	condition := bson.D{{Key: k.Column, Value: bson.D{{Key: operator, Value: key}}}}
+	if filter == nil {
+		return condition, findOptions, nil
+	}
+	return bson.D{{Key: "$and", Value: bson.A{filter, condition}}}, findOptions, nil
+}
@@ -0,0 +1,65 @@
+package pagination
+
+import (
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+)
+
+type MongoSuite struct {
+	suite.Suite
+}
+
+func (suite *MongoSuite) TestOffsetFindOptions() {
+	result := OffsetFindOptions(Offset{Row: 10, Page: 3})
+	suite.Equal(int64(20), *result.Skip)
+	suite.Equal(int64(10), *result.Limit)
+}
+
+func (suite *MongoSuite) TestKeysetMongoFirstPage() {
+	filter, result, err := Keyset{Column: "_id"}.Mongo(nil, Cursor{}, 10)
+	suite.NoError(err)
+	suite.Equal(bson.D{}, filter)
+	suite.Equal(bson.D{{Key: "_id", Value: 1}}, result.Sort)
+	suite.Equal(int64(11), *result.Limit)
+}
+
+func (suite *MongoSuite) TestKeysetMongo() {
+	filter, result, err := Keyset{Column: "_id", Desc: true}.Mongo(nil, Cursor{Key: "a"}, 10)
+	suite.NoError(err)
+	suite.Equal(bson.D{{Key: "_id", Value: bson.D{{Key: "$lt", Value: "a"}}}}, filter)
+	suite.Equal(bson.D{{Key: "_id", Value: -1}}, result.Sort)
+}
+
+func (suite *MongoSuite) TestKeysetMongoWithFilter() {
+	filter, _, err := Keyset{Column: "_id"}.Mongo(bson.M{"name": "max"}, Cursor{Key: "a"}, 10)
+	suite.NoError(err)
+	suite.Equal(bson.D{{Key: "$and", Value: bson.A{
+		bson.M{"name": "max"},
+		bson.D{{Key: "_id", Value: bson.D{{Key: "$gt", Value: "a"}}}},
+	}}}, filter)
+}
+
+func (suite *MongoSuite) TestKeysetMongoObjectIDKey() {
+	id := primitive.NewObjectID()
+	filter, _, err := Keyset{Column: "_id", Decode: ObjectIDKey}.Mongo(nil, Cursor{Key: id.Hex()}, 10)
+	suite.NoError(err)
+	suite.Equal(bson.D{{Key: "_id", Value: bson.D{{Key: "$gt", Value: id}}}}, filter)
+}
+
+func (suite *MongoSuite) TestKeysetMongoInt64Key() {
+	filter, _, err := Keyset{Column: "seq", Decode: Int64Key}.Mongo(nil, Cursor{Key: "42", Backward: true}, 10)
+	suite.NoError(err)
+	suite.Equal(bson.D{{Key: "seq", Value: bson.D{{Key: "$lt", Value: int64(42)}}}}, filter)
+}
+
+func (suite *MongoSuite) TestKeysetMongoInvalidKey() {
+	_, _, err := Keyset{Column: "_id", Decode: ObjectIDKey}.Mongo(nil, Cursor{Key: "a"}, 10)
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+}
+
+func TestMongoSuite(t *testing.T) {
+	suite.Run(t, new(MongoSuite))
+}
@@ -0,0 +1,180 @@
+package pagination
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/justdomepaul/toolbox/base58"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strconv"
+	"time"
+)
+
+// Offset type
+// page based pagination, Page starts from 1
+type Offset struct {
+	Row  int64
+	Page int64
+}
+
+// Limit method
+func (o Offset) Limit() int64 {
+	return o.Row
+}
+
+// Skip method
+// number of rows before current page
+func (o Offset) Skip() int64 {
+	if o.Page < 1 {
+		return 0
+	}
+	return (o.Page - 1) * o.Row
+}
+
+// HasNext method
+func (o Offset) HasNext(count int64) bool {
+	return o.Page*o.Row < count
+}
+
+// HasPrev method
+func (o Offset) HasPrev() bool {
+	return o.Page > 1
+}
+
+// Cursor type
+// opaque keyset pagination position, Key is the boundary row key value
+type Cursor struct {
+	Key      string `json:"k"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// Encode method
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base58.Encode(raw)
+}
+
+// IsZero method
+func (c Cursor) IsZero() bool {
+	return c == Cursor{}
+}
+
+// DecodeCursor method
+// empty token returns zero Cursor, which means the first page
+func DecodeCursor(token string) (Cursor, error) {
+	cursor := Cursor{}
+	if token == "" {
+		return cursor, nil
+	}
+	raw := base58.Decode(token)
+	if len(raw) == 0 {
+		return cursor, fmt.Errorf("%w: cursor format error", errorhandler.ErrInvalidArguments)
+	}
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return Cursor{}, fmt.Errorf("%w: cursor format error", errorhandler.ErrInvalidArguments)
+	}
+	return cursor, nil
+}
+
+// KeyDecoder type
+// converts Cursor.Key into value of Keyset column type, e.g. Int64Key for INT64 column
+type KeyDecoder func(key string) (interface{}, error)
+
+// StringKey method
+// Cursor.Key as is, used when Keyset.Decode is nil
+func StringKey(key string) (interface{}, error) {
+	return key, nil
+}
+
+// Int64Key method
+// key encoded by strconv.FormatInt
+func Int64Key(key string) (interface{}, error) {
+	value, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return nil, keyFormatError()
+	}
+	return value, nil
+}
+
+// ObjectIDKey method
+// key encoded by primitive.ObjectID Hex
+func ObjectIDKey(key string) (interface{}, error) {
+	value, err := primitive.ObjectIDFromHex(key)
+	if err != nil {
+		return nil, keyFormatError()
+	}
+	return value, nil
+}
+
+// TimeKey method
+// key encoded by time.RFC3339Nano
+func TimeKey(key string) (interface{}, error) {
+	value, err := time.Parse(time.RFC3339Nano, key)
+	if err != nil {
+		return nil, keyFormatError()
+	}
+	return value, nil
+}
+
+func keyFormatError() error {
+	return fmt.Errorf("%w: cursor key format error", errorhandler.ErrInvalidArguments)
+}
+
+// Keyset type
+// describes the unique column which cursor pagination walks on,
+// Decode converts Cursor.Key into column type, StringKey when nil
+type Keyset struct {
+	Column string
+	Desc   bool
+	Decode KeyDecoder
+}
+
+// value typed key of cursor
+func (k Keyset) value(cursor Cursor) (interface{}, error) {
+	if k.Decode == nil {
+		return StringKey(cursor.Key)
+	}
+	return k.Decode(cursor.Key)
+}
+
+// ascending returns the query order to fetch rows after (or before if backward) the cursor
+func (k Keyset) ascending(cursor Cursor) bool {
+	return k.Desc == cursor.Backward
+}
+
+func (k Keyset) operator(cursor Cursor) string {
+	if k.ascending(cursor) {
+		return ">"
+	}
+	return "<"
+}
+
+func (k Keyset) order(cursor Cursor) string {
+	if k.ascending(cursor) {
+		return "ASC"
+	}
+	return "DESC"
+}
+
+// Request type
+// list query arguments, use Offset or Cursor
+type Request struct {
+	Row    int64  `form:"row,default=20" validate:"min=1,max=1000"`
+	Page   int64  `form:"page,default=1" validate:"min=1"`
+	Cursor string `form:"cursor"`
+}
+
+// Offset method
+func (r Request) Offset() Offset {
+	return Offset{Row: r.Row, Page: r.Page}
+}
+
+// IsCursor method
+func (r Request) IsCursor() bool {
+	return r.Cursor != ""
+}
+
+// DecodeCursor method
+func (r Request) DecodeCursor() (Cursor, error) {
+	return DecodeCursor(r.Cursor)
+}
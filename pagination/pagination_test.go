@@ -0,0 +1,71 @@
+package pagination
+
+import (
+	"github.com/justdomepaul/toolbox/base58"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PaginationSuite struct {
+	suite.Suite
+}
+
+func (suite *PaginationSuite) TestOffset() {
+	offset := Offset{Row: 20, Page: 3}
+	suite.Equal(int64(20), offset.Limit())
+	suite.Equal(int64(40), offset.Skip())
+	suite.True(offset.HasNext(61))
+	suite.False(offset.HasNext(60))
+	suite.True(offset.HasPrev())
+	suite.False(Offset{Row: 20, Page: 1}.HasPrev())
+	suite.Equal(int64(0), Offset{Row: 20}.Skip())
+}
+
+func (suite *PaginationSuite) TestCursorEncodeDecode() {
+	cursor := Cursor{Key: "2023-01-01T00:00:00Z", Backward: true}
+	result, err := DecodeCursor(cursor.Encode())
+	suite.NoError(err)
+	suite.Equal(cursor, result)
+}
+
+func (suite *PaginationSuite) TestDecodeCursorEmpty() {
+	result, err := DecodeCursor("")
+	suite.NoError(err)
+	suite.True(result.IsZero())
+}
+
+func (suite *PaginationSuite) TestDecodeCursorError() {
+	_, err := DecodeCursor("0OIl")
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+
+	_, err = DecodeCursor(base58.Encode([]byte("not json")))
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+}
+
+func (suite *PaginationSuite) TestKeysetDirection() {
+	asc := Keyset{Column: "ID"}
+	suite.Equal(">", asc.operator(Cursor{}))
+	suite.Equal("ASC", asc.order(Cursor{}))
+	suite.Equal("<", asc.operator(Cursor{Backward: true}))
+	suite.Equal("DESC", asc.order(Cursor{Backward: true}))
+
+	desc := Keyset{Column: "ID", Desc: true}
+	suite.Equal("<", desc.operator(Cursor{}))
+	suite.Equal("DESC", desc.order(Cursor{}))
+	suite.Equal(">", desc.operator(Cursor{Backward: true}))
+	suite.Equal("ASC", desc.order(Cursor{Backward: true}))
+}
+
+func (suite *PaginationSuite) TestRequest() {
+	request := Request{Row: 10, Page: 2, Cursor: Cursor{Key: "a"}.Encode()}
+	suite.Equal(Offset{Row: 10, Page: 2}, request.Offset())
+	suite.True(request.IsCursor())
+	cursor, err := request.DecodeCursor()
+	suite.NoError(err)
+	suite.Equal("a", cursor.Key)
+}
+
+func TestPaginationSuite(t *testing.T) {
+	suite.Run(t, new(PaginationSuite))
+}
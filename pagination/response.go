@@ -0,0 +1,105 @@
+package pagination
+
+import (
+	"github.com/justdomepaul/toolbox/entity"
+	"net/url"
+	"strconv"
+)
+
+// Links type
+type Links struct {
+	Self string `json:"Self,omitempty"`
+	Next string `json:"Next,omitempty"`
+	Prev string `json:"Prev,omitempty"`
+}
+
+// Response type
+// list response envelope
+type Response[T any] struct {
+	entity.CommonListResponse
+	Items []T   `json:"Items"`
+	Links Links `json:"Links"`
+}
+
+// NewOffsetResponse method
+// count is the total rows of the list, u is the request url used to build links
+func NewOffsetResponse[T any](u *url.URL, offset Offset, count int64, items []T) Response[T] {
+	if items == nil {
+		items = make([]T, 0)
+	}
+	response := Response[T]{
+		CommonListResponse: entity.CommonListResponse{
+			Count: count,
+			Row:   offset.Row,
+			Page:  offset.Page,
+		},
+		Items: items,
+		Links: Links{Self: u.RequestURI()},
+	}
+	if offset.HasNext(count) {
+		response.Links.Next = pageLink(u, offset.Page+1)
+	}
+	if offset.HasPrev() {
+		response.Links.Prev = pageLink(u, offset.Page-1)
+	}
+	return response
+}
+
+// NewCursorResponse method
+// items must be fetched by Keyset query builders, which query one extra row to detect more pages,
+// key returns the Keyset column value of the item encoded for Keyset.Decode, e.g. strconv.FormatInt for Int64Key
+func NewCursorResponse[T any](u *url.URL, row int64, cursor Cursor, items []T, key func(T) string) Response[T] {
+	hasMore := int64(len(items)) > row
+	if hasMore {
+		items = items[:row]
+	}
+	if cursor.Backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+	if items == nil {
+		items = make([]T, 0)
+	}
+	response := Response[T]{
+		CommonListResponse: entity.CommonListResponse{
+			Row: row,
+		},
+		Items: items,
+		Links: Links{Self: u.RequestURI()},
+	}
+	if len(items) == 0 {
+		return response
+	}
+	hasNext := hasMore
+	hasPrev := !cursor.IsZero()
+	if cursor.Backward {
+		hasNext, hasPrev = true, hasMore
+	}
+	if hasNext {
+		response.NextCursor = Cursor{Key: key(items[len(items)-1])}.Encode()
+		response.Links.Next = cursorLink(u, response.NextCursor)
+	}
+	if hasPrev {
+		response.PrevCursor = Cursor{Key: key(items[0]), Backward: true}.Encode()
+		response.Links.Prev = cursorLink(u, response.PrevCursor)
+	}
+	return response
+}
+
+func pageLink(u *url.URL, page int64) string {
+	link := *u
+	query := link.Query()
+	query.Set("page", strconv.FormatInt(page, 10))
+	link.RawQuery = query.Encode()
+	return link.RequestURI()
+}
+
+func cursorLink(u *url.URL, cursor string) string {
+	link := *u
+	query := link.Query()
+	query.Del("page")
+	query.Set("cursor", cursor)
+	link.RawQuery = query.Encode()
+	return link.RequestURI()
+}
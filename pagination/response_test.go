@@ -0,0 +1,75 @@
+package pagination
+
+import (
+	"github.com/stretchr/testify/suite"
+	"net/url"
+	"testing"
+)
+
+type ResponseSuite struct {
+	suite.Suite
+	u *url.URL
+}
+
+func (suite *ResponseSuite) SetupTest() {
+	u, err := url.Parse("/api/users?page=2&row=10&name=max")
+	suite.NoError(err)
+	suite.u = u
+}
+
+func (suite *ResponseSuite) TestNewOffsetResponse() {
+	result := NewOffsetResponse(suite.u, Offset{Row: 10, Page: 2}, 35, []string{"a", "b"})
+	suite.Equal(int64(35), result.Count)
+	suite.Equal(int64(10), result.Row)
+	suite.Equal(int64(2), result.Page)
+	suite.Equal([]string{"a", "b"}, result.Items)
+	suite.Equal("/api/users?page=2&row=10&name=max", result.Links.Self)
+	suite.Equal("/api/users?name=max&page=3&row=10", result.Links.Next)
+	suite.Equal("/api/users?name=max&page=1&row=10", result.Links.Prev)
+}
+
+func (suite *ResponseSuite) TestNewOffsetResponseLastPage() {
+	result := NewOffsetResponse[string](suite.u, Offset{Row: 10, Page: 1}, 5, nil)
+	suite.NotNil(result.Items)
+	suite.Empty(result.Links.Next)
+	suite.Empty(result.Links.Prev)
+}
+
+func (suite *ResponseSuite) TestNewCursorResponseFirstPage() {
+	result := NewCursorResponse(suite.u, 2, Cursor{}, []string{"a", "b", "c"}, func(s string) string { return s })
+	suite.Equal([]string{"a", "b"}, result.Items)
+	suite.Equal(Cursor{Key: "b"}.Encode(), result.NextCursor)
+	suite.Empty(result.PrevCursor)
+	suite.Equal("/api/users?cursor="+result.NextCursor+"&name=max&row=10", result.Links.Next)
+	suite.Empty(result.Links.Prev)
+}
+
+func (suite *ResponseSuite) TestNewCursorResponseLastPage() {
+	result := NewCursorResponse(suite.u, 2, Cursor{Key: "b"}, []string{"c"}, func(s string) string { return s })
+	suite.Equal([]string{"c"}, result.Items)
+	suite.Empty(result.NextCursor)
+	suite.Equal(Cursor{Key: "c", Backward: true}.Encode(), result.PrevCursor)
+}
+
+func (suite *ResponseSuite) TestNewCursorResponseBackward() {
+	result := NewCursorResponse(suite.u, 2, Cursor{Key: "d", Backward: true}, []string{"c", "b", "a"}, func(s string) string { return s })
+	suite.Equal([]string{"b", "c"}, result.Items)
+	suite.Equal(Cursor{Key: "c"}.Encode(), result.NextCursor)
+	suite.Equal(Cursor{Key: "b", Backward: true}.Encode(), result.PrevCursor)
+
+	result = NewCursorResponse(suite.u, 2, Cursor{Key: "c", Backward: true}, []string{"b", "a"}, func(s string) string { return s })
+	suite.Equal([]string{"a", "b"}, result.Items)
+	suite.Equal(Cursor{Key: "b"}.Encode(), result.NextCursor)
+	suite.Empty(result.PrevCursor)
+}
+
+func (suite *ResponseSuite) TestNewCursorResponseEmpty() {
+	result := NewCursorResponse[string](suite.u, 2, Cursor{Key: "c"}, nil, func(s string) string { return s })
+	suite.NotNil(result.Items)
+	suite.Empty(result.NextCursor)
+	suite.Empty(result.PrevCursor)
+}
+
+func TestResponseSuite(t *testing.T) {
+	suite.Run(t, new(ResponseSuite))
+}
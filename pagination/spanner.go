@@ -0,0 +1,62 @@
+package pagination
+
+import (
+	"cloud.google.com/go/spanner"
+	"github.com/justdomepaul/toolbox/stringtool"
+)
+
+const (
+	spannerLimitParam  = "pageLimit"
+	spannerOffsetParam = "pageOffset"
+	spannerCursorParam = "pageCursor"
+)
+
+// OffsetStatement method
+// stmt SQL should contain ORDER BY clause to get stable pages
+func OffsetStatement(stmt spanner.Statement, offset Offset) spanner.Statement {
+	params := copyParams(stmt.Params)
+	params[spannerLimitParam] = offset.Limit()
+	params[spannerOffsetParam] = offset.Skip()
+	return spanner.Statement{
+		SQL:    stringtool.StringJoin(stmt.SQL, " LIMIT @", spannerLimitParam, " OFFSET @", spannerOffsetParam),
+		Params: params,
+	}
+}
+
+// CountStatement method
+// returns total rows of stmt in Count column
+func CountStatement(stmt spanner.Statement) spanner.Statement {
+	return spanner.Statement{
+		SQL:    stringtool.StringJoin("SELECT COUNT(*) AS Count FROM (", stmt.SQL, ")"),
+		Params: copyParams(stmt.Params),
+	}
+}
+
+// Statement method
+// wraps stmt to fetch row+1 rows after the cursor ordered by Keyset column,
+// error when cursor key cannot be decoded by Keyset.Decode
+func (k Keyset) Statement(stmt spanner.Statement, cursor Cursor, row int64) (spanner.Statement, error) {
+	params := copyParams(stmt.Params)
+	sql := stringtool.StringJoin("SELECT * FROM (", stmt.SQL, ")")
+	if !cursor.IsZero() {
+		key, err := k.value(cursor)
+		if err != nil {
+			return spanner.Statement{}, err
+		}
+		sql = stringtool.StringJoin(sql, " WHERE ", k.Column, " ", k.operator(cursor), " @", spannerCursorParam)
+		params[spannerCursorParam] = key
+	}
+	params[spannerLimitParam] = row + 1
+	return spanner.Statement{
+		SQL:    stringtool.StringJoin(sql, " ORDER BY ", k.Column, " ", k.order(cursor), " LIMIT @", spannerLimitParam),
+		Params: params,
+	}, nil
+}
+
+func copyParams(params map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(params)+3)
+	for key, value := range params {
+		result[key] = value
+	}
+	return result
+}
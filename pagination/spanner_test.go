@@ -0,0 +1,70 @@
+package pagination
+
+import (
+	"cloud.google.com/go/spanner"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type SpannerSuite struct {
+	suite.Suite
+	stmt spanner.Statement
+}
+
+func (suite *SpannerSuite) SetupTest() {
+	suite.stmt = spanner.Statement{
+		SQL:    "SELECT ID, Name FROM Users WHERE Name = @name",
+		Params: map[string]interface{}{"name": "max"},
+	}
+}
+
+func (suite *SpannerSuite) TestOffsetStatement() {
+	result := OffsetStatement(suite.stmt, Offset{Row: 10, Page: 3})
+	suite.Equal("SELECT ID, Name FROM Users WHERE Name = @name LIMIT @pageLimit OFFSET @pageOffset", result.SQL)
+	suite.Equal(map[string]interface{}{"name": "max", "pageLimit": int64(10), "pageOffset": int64(20)}, result.Params)
+	suite.Len(suite.stmt.Params, 1)
+}
+
+func (suite *SpannerSuite) TestCountStatement() {
+	result := CountStatement(suite.stmt)
+	suite.Equal("SELECT COUNT(*) AS Count FROM (SELECT ID, Name FROM Users WHERE Name = @name)", result.SQL)
+	suite.Equal(suite.stmt.Params, result.Params)
+}
+
+func (suite *SpannerSuite) TestKeysetStatementFirstPage() {
+	result, err := Keyset{Column: "ID"}.Statement(suite.stmt, Cursor{}, 10)
+	suite.NoError(err)
+	suite.Equal("SELECT * FROM (SELECT ID, Name FROM Users WHERE Name = @name) ORDER BY ID ASC LIMIT @pageLimit", result.SQL)
+	suite.Equal(map[string]interface{}{"name": "max", "pageLimit": int64(11)}, result.Params)
+}
+
+func (suite *SpannerSuite) TestKeysetStatement() {
+	result, err := Keyset{Column: "ID", Desc: true}.Statement(suite.stmt, Cursor{Key: "a"}, 10)
+	suite.NoError(err)
+	suite.Equal("SELECT * FROM (SELECT ID, Name FROM Users WHERE Name = @name) WHERE ID < @pageCursor ORDER BY ID DESC LIMIT @pageLimit", result.SQL)
+	suite.Equal(map[string]interface{}{"name": "max", "pageCursor": "a", "pageLimit": int64(11)}, result.Params)
+}
+
+func (suite *SpannerSuite) TestKeysetStatementInt64Key() {
+	result, err := Keyset{Column: "ID", Decode: Int64Key}.Statement(suite.stmt, Cursor{Key: "42"}, 10)
+	suite.NoError(err)
+	suite.Equal(map[string]interface{}{"name": "max", "pageCursor": int64(42), "pageLimit": int64(11)}, result.Params)
+}
+
+func (suite *SpannerSuite) TestKeysetStatementTimeKey() {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC)
+	result, err := Keyset{Column: "CreatedAt", Decode: TimeKey}.Statement(suite.stmt, Cursor{Key: createdAt.Format(time.RFC3339Nano)}, 10)
+	suite.NoError(err)
+	suite.Equal(createdAt, result.Params["pageCursor"])
+}
+
+func (suite *SpannerSuite) TestKeysetStatementInvalidKey() {
+	_, err := Keyset{Column: "ID", Decode: Int64Key}.Statement(suite.stmt, Cursor{Key: "a"}, 10)
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+}
+
+func TestSpannerSuite(t *testing.T) {
+	suite.Run(t, new(SpannerSuite))
+}
@@ -0,0 +1,42 @@
+package pagination
+
+import (
+	"fmt"
+)
+
+// OffsetSQL method
+// appends LIMIT and OFFSET to Postgres/Cockroach query which uses $n placeholders,
+// query should contain ORDER BY clause to get stable pages
+func OffsetSQL(query string, offset Offset, args ...interface{}) (string, []interface{}) {
+	n := len(args)
+	return fmt.Sprintf("%s LIMIT $%d OFFSET $%d", query, n+1, n+2),
+		appendArgs(args, offset.Limit(), offset.Skip())
+}
+
+// CountSQL method
+func CountSQL(query string) string {
+	return fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS counted", query)
+}
+
+// SQL method
+// wraps Postgres/Cockroach query to fetch row+1 rows after the cursor ordered by Keyset column,
+// error when cursor key cannot be decoded by Keyset.Decode
+func (k Keyset) SQL(query string, cursor Cursor, row int64, args ...interface{}) (string, []interface{}, error) {
+	sql := fmt.Sprintf("SELECT * FROM (%s) AS paged", query)
+	if !cursor.IsZero() {
+		key, err := k.value(cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		args = appendArgs(args, key)
+		sql = fmt.Sprintf("%s WHERE %s %s $%d", sql, k.Column, k.operator(cursor), len(args))
+	}
+	args = appendArgs(args, row+1)
+	return fmt.Sprintf("%s ORDER BY %s %s LIMIT $%d", sql, k.Column, k.order(cursor), len(args)), args, nil
+}
+
+func appendArgs(args []interface{}, values ...interface{}) []interface{} {
+	result := make([]interface{}, 0, len(args)+len(values))
+	result = append(result, args...)
+	return append(result, values...)
+}
@@ -0,0 +1,53 @@
+package pagination
+
+import (
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SQLSuite struct {
+	suite.Suite
+}
+
+func (suite *SQLSuite) TestOffsetSQL() {
+	args := []interface{}{"max"}
+	query, result := OffsetSQL("SELECT id, name FROM users WHERE name = $1 ORDER BY id", Offset{Row: 10, Page: 2}, args...)
+	suite.Equal("SELECT id, name FROM users WHERE name = $1 ORDER BY id LIMIT $2 OFFSET $3", query)
+	suite.Equal([]interface{}{"max", int64(10), int64(10)}, result)
+	suite.Len(args, 1)
+}
+
+func (suite *SQLSuite) TestCountSQL() {
+	suite.Equal("SELECT COUNT(*) FROM (SELECT id FROM users) AS counted", CountSQL("SELECT id FROM users"))
+}
+
+func (suite *SQLSuite) TestKeysetSQLFirstPage() {
+	query, result, err := Keyset{Column: "id"}.SQL("SELECT id, name FROM users", Cursor{}, 10)
+	suite.NoError(err)
+	suite.Equal("SELECT * FROM (SELECT id, name FROM users) AS paged ORDER BY id ASC LIMIT $1", query)
+	suite.Equal([]interface{}{int64(11)}, result)
+}
+
+func (suite *SQLSuite) TestKeysetSQL() {
+	query, result, err := Keyset{Column: "id"}.SQL("SELECT id, name FROM users WHERE name = $1", Cursor{Key: "a", Backward: true}, 10, "max")
+	suite.NoError(err)
+	suite.Equal("SELECT * FROM (SELECT id, name FROM users WHERE name = $1) AS paged WHERE id < $2 ORDER BY id DESC LIMIT $3", query)
+	suite.Equal([]interface{}{"max", "a", int64(11)}, result)
+}
+
+func (suite *SQLSuite) TestKeysetSQLInt64Key() {
+	query, result, err := Keyset{Column: "id", Decode: Int64Key}.SQL("SELECT id, name FROM users", Cursor{Key: "42"}, 10)
+	suite.NoError(err)
+	suite.Equal("SELECT * FROM (SELECT id, name FROM users) AS paged WHERE id > $1 ORDER BY id ASC LIMIT $2", query)
+	suite.Equal([]interface{}{int64(42), int64(11)}, result)
+}
+
+func (suite *SQLSuite) TestKeysetSQLInvalidKey() {
+	_, _, err := Keyset{Column: "id", Decode: Int64Key}.SQL("SELECT id, name FROM users", Cursor{Key: "a"}, 10)
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+}
+
+func TestSQLSuite(t *testing.T) {
+	suite.Run(t, new(SQLSuite))
+}
@@ -3,9 +3,11 @@ package restful
 import (
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/audit"
 	"github.com/justdomepaul/toolbox/definition"
 	"github.com/justdomepaul/toolbox/errorhandler"
 	jwtTool "github.com/justdomepaul/toolbox/jwt"
+	zapTool "github.com/justdomepaul/toolbox/zap"
 	"strings"
 )
 
@@ -16,20 +18,52 @@ func NewBasicGuardValidator(jwt jwtTool.IJWT) *BasicGuardValidator {
 }
 
 type BasicGuardValidator struct {
-	jwt jwtTool.IJWT
+	jwt     jwtTool.IJWT
+	auditor audit.IAuditor
+}
+
+// SetAuditor method
+// records authorization decisions, set before serving
+func (b *BasicGuardValidator) SetAuditor(auditor audit.IAuditor) {
+	b.auditor = auditor
 }
 
 func (b *BasicGuardValidator) Verify(c *gin.Context, token string) error {
 	commonClaims := jwtTool.NewCommon(jwtTool.NewClaimsBuilder().Build())
 	if err := b.jwt.VerifyToken(token, commonClaims); err != nil {
+		b.record(c, audit.Event{Decision: audit.DecisionDeny, Reason: err.Error()})
 		return errorhandler.NewErrAuthenticate(err)
 	}
 
+	event := audit.Event{ClientID: zapTool.FormatClientID(commonClaims.ClientID)}
+	if commonClaims.Claims != nil {
+		event.TokenID = commonClaims.ID
+	}
 	for _, permission := range commonClaims.Permissions {
 		if strings.HasPrefix(c.FullPath(), permission) || strings.HasPrefix(c.Request.RequestURI, permission) {
 			c.Set(definition.AuthTokenKey, commonClaims)
+			c.Set(definition.AuthorizationID, commonClaims.ClientID)
+			event.Decision = audit.DecisionAllow
+			b.record(c, event)
 			return nil
 		}
 	}
-	return errorhandler.NewErrPermissionDeny(errors.New("no permission allowed to resource"))
+	err := errors.New("no permission allowed to resource")
+	event.Decision, event.Reason = audit.DecisionDeny, err.Error()
+	b.record(c, event)
+	return errorhandler.NewErrPermissionDeny(err)
+}
+
+func (b *BasicGuardValidator) record(c *gin.Context, event audit.Event) {
+	if b.auditor == nil {
+		return
+	}
+	event.Method = c.Request.Method
+	event.Path = c.FullPath()
+	if event.Path == "" {
+		event.Path = c.Request.RequestURI
+	}
+	event.SourceIP = c.ClientIP()
+	event.RequestID = zapTool.RequestID(c)
+	b.auditor.Record(event)
 }
@@ -2,7 +2,9 @@ package restful
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/audit"
 	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/definition"
 	"github.com/justdomepaul/toolbox/jwt"
 	"github.com/stretchr/testify/suite"
 	"net/http"
@@ -43,6 +45,7 @@ XC9HWu76KhJnPpHczvZZv7Rro+kmqvN5tw==
 	token, err := suite.jwt.GenerateToken(jwt.NewCommon(
 		jwt.NewClaimsBuilder().ExpiresAfter(500*time.Second).Build(),
 		jwt.WithPermissions("/ping"),
+		jwt.WithClientID("client"),
 	))
 	suite.NoError(err)
 	suite.token = token
@@ -68,6 +71,7 @@ func (suite *BasicGuardValidatorSuite) TestNewBasicGuardValidator() {
 
 func (suite *BasicGuardValidatorSuite) TestVerify() {
 	suite.NoError(NewBasicGuardValidator(suite.jwt).Verify(suite.c, suite.token))
+	suite.Equal([]byte("client"), suite.c.MustGet(definition.AuthorizationID))
 }
 
 func (suite *BasicGuardValidatorSuite) TestVerifyExpired() {
@@ -78,6 +82,34 @@ func (suite *BasicGuardValidatorSuite) TestVerifyNoExpired() {
 	suite.NoError(NewBasicGuardValidator(suite.jwt).Verify(suite.c, suite.noneToken))
 }
 
+func (suite *BasicGuardValidatorSuite) TestSetAuditor() {
+	auditor := &testAuditor{}
+	validator := NewBasicGuardValidator(suite.jwt)
+	validator.SetAuditor(auditor)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/pong", nil)
+	c.Request.RemoteAddr = "10.0.0.1:5000"
+	c.Set(definition.RequestIDKey, "request")
+
+	suite.Error(validator.Verify(c, suite.expiredToken))
+	suite.Error(validator.Verify(c, suite.token))
+	c.Request.RequestURI = "/ping"
+	suite.NoError(validator.Verify(c, suite.token))
+
+	suite.Len(auditor.events, 3)
+	suite.Equal(audit.DecisionDeny, auditor.events[0].Decision)
+	suite.Equal(audit.Event{Method: http.MethodGet, Path: "/pong", ClientID: "client", Decision: audit.DecisionDeny, Reason: "no permission allowed to resource", SourceIP: "10.0.0.1", RequestID: "request"}, auditor.events[1])
+	suite.Equal(audit.Event{Method: http.MethodGet, Path: "/ping", ClientID: "client", Decision: audit.DecisionAllow, SourceIP: "10.0.0.1", RequestID: "request"}, auditor.events[2])
+}
+
 func TestBasicGuardValidatorSuite(t *testing.T) {
 	suite.Run(t, new(BasicGuardValidatorSuite))
 }
+
+type testAuditor struct {
+	events []audit.Event
+}
+
+func (t *testAuditor) Record(event audit.Event) {
+	t.events = append(t.events, event)
+}
@@ -5,14 +5,56 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/justdomepaul/toolbox/config"
 	"github.com/justdomepaul/toolbox/errorhandler"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"go.uber.org/zap"
 	"html/template"
-	"net/http"
+	"sync/atomic"
 )
 
+// LogLevelPath path of log level handler mounted when config.Server.LogLevelHandler enabled
+const LogLevelPath = "/debug/loglevel"
+
+// ReadinessPath path of shutdown.Shutdown ReadinessHandler mounted by app.New
+const ReadinessPath = "/ready"
+
+// AllowOrigins type
+// CORS allowed origins of engine built by NewGin, replaceable at runtime, "*" allows all origins
+type AllowOrigins struct {
+	origins atomic.Pointer[[]string]
+}
+
+// NewAllowOrigins method
+func NewAllowOrigins(option config.Server) *AllowOrigins {
+	a := &AllowOrigins{}
+	a.Set(option.AllowOrigins)
+	return a
+}
+
+// Set method
+// overrides allowed origins at runtime, e.g. on config.Watcher change:
+//
+//	config.Subscribe(watcher, config.NewServer, func(server config.Server) { origins.Set(server.AllowOrigins) })
+func (a *AllowOrigins) Set(origins []string) {
+	origins = append([]string{}, origins...)
+	a.origins.Store(&origins)
+}
+
+// Allow method
+func (a *AllowOrigins) Allow(origin string) bool {
+	for _, o := range *a.origins.Load() {
+		if o == "*" || o == origin {
+			return true
+		}
+	}
+	return false
+}
+
 func NewGin(
 	option config.Set,
+	logger *zap.Logger,
 	render *Render,
 	guarder *JWTGuarder,
+	origins *AllowOrigins,
 ) (*gin.Engine, error) {
 	if option.Server.ReleaseMode {
 		gin.SetMode(gin.ReleaseMode)
@@ -20,6 +62,8 @@ func NewGin(
 		gin.SetMode(gin.DebugMode)
 	}
 	srv := gin.New()
+	// let *gin.Context passed as context.Context (e.g. by Handle) fall back to request context
+	srv.ContextWithFallback = true
 
 	if option.Server.CustomizedRender {
 		tmpl, err := template.New("tmpl").Parse(ErrorPageTmpl)
@@ -34,41 +78,55 @@ func NewGin(
 
 	srv.MaxMultipartMemory = option.Server.MaxMultipartMemoryMB << 20
 
+	if err := srv.SetTrustedProxies(option.Server.TrustedProxies); err != nil {
+		return nil, err
+	}
+	srv.TrustedPlatform = option.Server.TrustedPlatform
+
 	cf := cors.DefaultConfig()
-	cf.AllowMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions}
-	cf.AllowHeaders = []string{
-		"Origin",
-		"Upgrade",
-		"Content-Length",
-		"Content-Type",
-		"Authorization",
-		"Connection",
-		"Accept-Encoding",
-		"Accept-Language",
-		"Host",
-		"X-Google-*",
-		"X-AppEngine-*",
-		"X-CloudScheduler",
-		"X-CloudScheduler-JobName",
-		"X-CloudScheduler-ScheduleTime",
-		"Sec-WebSocket-Key",
-		"Sec-WebSocket-Version",
-		"Sec-WebSocket-Protocol",
+	if len(option.Server.AllowMethods) > 0 {
+		cf.AllowMethods = option.Server.AllowMethods
+	}
+	if len(option.Server.AllowHeaders) > 0 {
+		cf.AllowHeaders = option.Server.AllowHeaders
+	}
+	cf.ExposeHeaders = option.Server.ExposeHeaders
+	cf.AllowCredentials = option.Server.AllowCredentials
+	if option.Server.CorsMaxAge > 0 {
+		cf.MaxAge = option.Server.CorsMaxAge
 	}
 	if option.Server.AllowAllOrigins {
 		cf.AllowAllOrigins = true
 	} else {
 		cf.AllowOrigins = option.Server.AllowOrigins
 	}
+	if err := cf.Validate(); err != nil {
+		return nil, err
+	}
+	if !option.Server.AllowAllOrigins {
+		// checked by origins, replaceable at runtime by AllowOrigins.Set
+		cf.AllowAllOrigins, cf.AllowOrigins, cf.AllowOriginFunc = false, nil, origins.Allow
+	}
 	fns := []gin.HandlerFunc{
 		cors.New(cf),
+		RequestLogger(logger),
 		gin.Logger(),
 		errorhandler.GinPanicErrorHandler(option.Core.SystemName, option.Server.PrefixMessage),
+		errorhandler.GinErrorHandler(option.Core.SystemName, option.Server.PrefixMessage),
+	}
+	if option.Server.SecurityHeaders {
+		fns = append(fns, SecurityHeaders(option.Server))
 	}
 	if option.Server.JWTGuard {
-		fns = append(fns, guarder.JWTGuarder(option.Server.AllowedPaths...))
+		fns = append(fns, guarder.Guard(option.Server.AllowedPaths...))
 	}
 	srv.Use(fns...)
 
+	if option.Server.LogLevelHandler {
+		handler := gin.WrapH(zapTool.LevelHandler())
+		srv.GET(LogLevelPath, handler)
+		srv.PUT(LogLevelPath, handler)
+	}
+
 	return srv, nil
 }
@@ -1,10 +1,19 @@
 package restful
 
 import (
+	"github.com/gin-gonic/gin"
 	"github.com/justdomepaul/toolbox/config"
+	zapTool "github.com/justdomepaul/toolbox/zap"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 type GinSuite struct {
@@ -38,23 +47,206 @@ func (suite *GinSuite) SetupSuite() {
 }
 
 func (suite *GinSuite) TestNewGin() {
-	gin, err := NewGin(suite.option, NewRender(), &JWTGuarder{})
+	gin, err := NewGin(suite.option, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(suite.option.Server))
 	suite.NoError(err)
 	suite.Equal("*gin.Engine", reflect.TypeOf(gin).String())
 }
 
 func (suite *GinSuite) TestNewGinAllowOrigins() {
-	gin, err := NewGin(suite.option, NewRender(), &JWTGuarder{})
+	gin, err := NewGin(suite.option, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(suite.option.Server))
 	suite.NoError(err)
 	suite.Equal("*gin.Engine", reflect.TypeOf(gin).String())
 }
 
 func (suite *GinSuite) TestNewGinAllowOriginsReleaseAndLimitOrigin() {
-	gin, err := NewGin(suite.anotherOption, NewRender(), &JWTGuarder{})
+	gin, err := NewGin(suite.anotherOption, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(suite.anotherOption.Server))
 	suite.NoError(err)
 	suite.Equal("*gin.Engine", reflect.TypeOf(gin).String())
 }
 
+func (suite *GinSuite) TestNewGinCorsOption() {
+	option := suite.anotherOption
+	option.Server.AllowMethods = []string{http.MethodGet, http.MethodPatch}
+	option.Server.AllowHeaders = []string{"Authorization", "X-Request-ID"}
+	option.Server.ExposeHeaders = []string{"X-Request-ID"}
+	option.Server.AllowCredentials = true
+	option.Server.CorsMaxAge = time.Hour
+	srv, err := NewGin(option, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(option.Server))
+	suite.NoError(err)
+	srv.GET("/ping", QuickReply())
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodOptions, "/ping", nil)
+	req.Header.Set("Origin", "http://localhost")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+	srv.ServeHTTP(w, req)
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Equal("GET,PATCH", w.Header().Get("Access-Control-Allow-Methods"))
+	suite.Equal("Authorization,X-Request-Id", w.Header().Get("Access-Control-Allow-Headers"))
+	suite.Equal("true", w.Header().Get("Access-Control-Allow-Credentials"))
+	suite.Equal("3600", w.Header().Get("Access-Control-Max-Age"))
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("Origin", "http://localhost")
+	srv.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("X-Request-Id", w.Header().Get("Access-Control-Expose-Headers"))
+}
+
+// allowOrigin serves request of origin by srv, returns Access-Control-Allow-Origin
+func allowOrigin(srv http.Handler, origin string) string {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("Origin", origin)
+	srv.ServeHTTP(w, req)
+	return w.Header().Get("Access-Control-Allow-Origin")
+}
+
+func (suite *GinSuite) TestAllowOriginsSet() {
+	origins := NewAllowOrigins(suite.anotherOption.Server)
+	srv, err := NewGin(suite.anotherOption, zap.NewNop(), NewRender(), &JWTGuarder{}, origins)
+	suite.NoError(err)
+	srv.GET("/ping", QuickReply())
+	other, err := NewGin(suite.anotherOption, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(suite.anotherOption.Server))
+	suite.NoError(err)
+	other.GET("/ping", QuickReply())
+	suite.Equal("http://localhost", allowOrigin(srv, "http://localhost"))
+	suite.Empty(allowOrigin(srv, "https://toolbox.dev"))
+
+	origins.Set([]string{"https://toolbox.dev"})
+	suite.Equal("https://toolbox.dev", allowOrigin(srv, "https://toolbox.dev"))
+	suite.Empty(allowOrigin(srv, "http://localhost"))
+	// origins of other engine are untouched
+	suite.Equal("http://localhost", allowOrigin(other, "http://localhost"))
+	suite.Empty(allowOrigin(other, "https://toolbox.dev"))
+}
+
+func (suite *GinSuite) TestAllowOriginsWildcard() {
+	option := suite.anotherOption
+	option.Server.AllowOrigins = []string{"*"}
+	origins := NewAllowOrigins(option.Server)
+	srv, err := NewGin(option, zap.NewNop(), NewRender(), &JWTGuarder{}, origins)
+	suite.NoError(err)
+	srv.GET("/ping", QuickReply())
+	suite.Equal("https://toolbox.dev", allowOrigin(srv, "https://toolbox.dev"))
+
+	origins.Set([]string{"http://localhost"})
+	suite.Empty(allowOrigin(srv, "https://toolbox.dev"))
+	origins.Set([]string{"http://localhost", "*"})
+	suite.Equal("https://toolbox.dev", allowOrigin(srv, "https://toolbox.dev"))
+}
+
+func (suite *GinSuite) TestNewGinCorsOptionError() {
+	option := suite.anotherOption
+	option.Server.AllowOrigins = nil
+	_, err := NewGin(option, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(option.Server))
+	suite.Error(err)
+}
+
+func (suite *GinSuite) TestNewGinSecurityHeaders() {
+	option := suite.anotherOption
+	option.Server.SecurityHeaders = true
+	option.Server.HSTSMaxAge = time.Hour
+	option.Server.FrameOptions = "DENY"
+	srv, err := NewGin(option, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(option.Server))
+	suite.NoError(err)
+	srv.GET("/ping", QuickReply())
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("max-age=3600", w.Header().Get(HeaderStrictTransportSecurity))
+	suite.Equal("DENY", w.Header().Get(HeaderFrameOptions))
+}
+
+func (suite *GinSuite) TestNewGinTrustedProxies() {
+	option := suite.anotherOption
+	option.Server.TrustedProxies = []string{"10.0.0.0/8"}
+	srv, err := NewGin(option, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(option.Server))
+	suite.NoError(err)
+	clientIP := ""
+	srv.GET("/ip", func(c *gin.Context) {
+		clientIP = c.ClientIP()
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = "10.1.1.1:8080"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	srv.ServeHTTP(w, req)
+	suite.Equal("203.0.113.7", clientIP)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = "172.16.1.1:8080"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	srv.ServeHTTP(w, req)
+	suite.Equal("172.16.1.1", clientIP)
+}
+
+func (suite *GinSuite) TestNewGinTrustNoProxiesByDefault() {
+	srv, err := NewGin(suite.anotherOption, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(suite.anotherOption.Server))
+	suite.NoError(err)
+	clientIP := ""
+	srv.GET("/ip", func(c *gin.Context) {
+		clientIP = c.ClientIP()
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = "10.1.1.1:8080"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	srv.ServeHTTP(w, req)
+	suite.Equal("10.1.1.1", clientIP)
+}
+
+func (suite *GinSuite) TestNewGinRequestLogger() {
+	core, logs := observer.New(zapcore.InfoLevel)
+	srv, err := NewGin(suite.anotherOption, zap.New(core), NewRender(), &JWTGuarder{}, NewAllowOrigins(suite.anotherOption.Server))
+	suite.NoError(err)
+	srv.GET("/log", func(c *gin.Context) {
+		zapTool.FromContext(c).Info("handled")
+	})
+
+	srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/log", nil))
+	suite.Equal(1, logs.FilterMessage("handled").Len())
+}
+
+func (suite *GinSuite) TestNewGinTrustedProxiesError() {
+	option := suite.anotherOption
+	option.Server.TrustedProxies = []string{"not-an-ip"}
+	_, err := NewGin(option, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(option.Server))
+	suite.Error(err)
+}
+
+func (suite *GinSuite) TestNewGinLogLevelHandler() {
+	defer zapTool.Level.SetLevel(zapTool.Level.Level())
+	option := suite.anotherOption
+	option.Server.LogLevelHandler = true
+	srv, err := NewGin(option, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(option.Server))
+	suite.NoError(err)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodPut, LogLevelPath, strings.NewReader(`{"level":"warn"}`)))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(zapcore.WarnLevel, zapTool.Level.Level())
+
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, LogLevelPath, nil))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"level":"warn"}`, w.Body.String())
+}
+
+func (suite *GinSuite) TestNewGinLogLevelHandlerDisabled() {
+	srv, err := NewGin(suite.anotherOption, zap.NewNop(), NewRender(), &JWTGuarder{}, NewAllowOrigins(suite.anotherOption.Server))
+	suite.NoError(err)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, LogLevelPath, nil))
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
 func TestGinSuite(t *testing.T) {
 	suite.Run(t, new(GinSuite))
 }
@@ -0,0 +1,273 @@
+package restful
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+var (
+	validate = newValidator()
+)
+
+// HandleOption interface
+type HandleOption interface {
+	Apply(*handleOption)
+}
+
+// WithStatusCode method
+// set success response status code, default http.StatusOK
+func WithStatusCode(code int) HandleOption {
+	return withStatusCode{code: code}
+}
+
+type withStatusCode struct {
+	code int
+}
+
+// Apply method
+func (w withStatusCode) Apply(h *handleOption) {
+	h.statusCode = w.code
+}
+
+// WithSystem method
+func WithSystem(system string) HandleOption {
+	return withSystem{system: system}
+}
+
+type withSystem struct {
+	system string
+}
+
+// Apply method
+func (w withSystem) Apply(h *handleOption) {
+	h.system = w.system
+}
+
+// WithPrefixMessage method
+func WithPrefixMessage(prefixMessage string) HandleOption {
+	return withPrefixMessage{prefixMessage: prefixMessage}
+}
+
+type withPrefixMessage struct {
+	prefixMessage string
+}
+
+// Apply method
+func (w withPrefixMessage) Apply(h *handleOption) {
+	h.prefixMessage = w.prefixMessage
+}
+
+type handleOption struct {
+	statusCode    int
+	system        string
+	prefixMessage string
+}
+
+// Handle method
+// binds path (uri tag), query (form tag), header (header tag) and body (json or form) into Req,
+// validates Req by go-playground validate tag, then renders fn result as JSON.
+// fn receives *gin.Context as ctx, error implemented errorhandler.IGinErrorReport renders by GinReport,
+// binding and validation failures render as errorhandler.ErrInvalidArgument with field violations.
+func Handle[Req, Resp any](fn func(ctx context.Context, req Req) (Resp, error), options ...HandleOption) gin.HandlerFunc {
+	option := &handleOption{
+		statusCode: http.StatusOK,
+	}
+	for _, o := range options {
+		o.Apply(option)
+	}
+	return func(c *gin.Context) {
+		req, err := Bind[Req](c)
+		if err != nil {
+			renderError(c, err, option)
+			return
+		}
+		resp, err := fn(c, req)
+		if err != nil {
+			renderError(c, err, option)
+			return
+		}
+		if option.statusCode == http.StatusNoContent {
+			c.Status(option.statusCode)
+			return
+		}
+		c.JSON(option.statusCode, resp)
+	}
+}
+
+// Bind method
+// binds and validates request like Handle, returns *errorhandler.ErrInvalidArgument when failed
+func Bind[Req any](c *gin.Context) (Req, error) {
+	var req Req
+	target := interface{}(&req)
+	if t := reflect.TypeOf(req); t != nil && t.Kind() == reflect.Pointer {
+		v := reflect.New(t.Elem())
+		reflect.ValueOf(&req).Elem().Set(v)
+		target = v.Interface()
+	}
+	if err := bindRequest(c, target); err != nil {
+		return req, errorhandler.NewErrInvalidArgument(fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error()))
+	}
+	if err := validateRequest(target); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func bindRequest(c *gin.Context, target interface{}) error {
+	if !isStruct(target) {
+		return bindBody(c, target)
+	}
+	if len(c.Params) > 0 {
+		params := make(map[string][]string, len(c.Params))
+		for _, param := range c.Params {
+			params[param.Key] = []string{param.Value}
+		}
+		if err := binding.MapFormWithTag(target, params, "uri"); err != nil {
+			return err
+		}
+	}
+	if err := binding.MapFormWithTag(target, c.Request.URL.Query(), "form"); err != nil {
+		return err
+	}
+	if err := binding.MapFormWithTag(target, headerValues(reflect.TypeOf(target), c.Request.Header), "header"); err != nil {
+		return err
+	}
+	return bindBody(c, target)
+}
+
+func bindBody(c *gin.Context, target interface{}) error {
+	if c.Request.Body == nil || c.Request.ContentLength == 0 {
+		return nil
+	}
+	switch c.ContentType() {
+	case binding.MIMEPOSTForm:
+		if err := c.Request.ParseForm(); err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(target, c.Request.PostForm, "form")
+	case binding.MIMEMultipartPOSTForm:
+		form, err := c.MultipartForm()
+		if err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(target, form.Value, "form")
+	case binding.MIMEJSON, "":
+		if err := json.NewDecoder(c.Request.Body).Decode(target); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported content type: %s", c.ContentType())
+	}
+}
+
+// headerValues collects header tagged fields value, header names are case-insensitive
+func headerValues(t reflect.Type, header http.Header) map[string][]string {
+	values := make(map[string][]string)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return values
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			for key, value := range headerValues(field.Type, header) {
+				values[key] = value
+			}
+			continue
+		}
+		name := strings.Split(field.Tag.Get("header"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		if value := header.Values(name); len(value) > 0 {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+func validateRequest(target interface{}) error {
+	if !isStruct(target) {
+		return nil
+	}
+	err := validate.Struct(target)
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		violations := make([]errorhandler.FieldViolation, 0, len(validationErrors))
+		for _, fieldError := range validationErrors {
+			violations = append(violations, toFieldViolation(fieldError))
+		}
+		return errorhandler.NewErrInvalidArgument(fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error()), violations...)
+	}
+	if err != nil {
+		return errorhandler.NewErrInvalidArgument(fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error()))
+	}
+	return nil
+}
+
+func toFieldViolation(fieldError validator.FieldError) errorhandler.FieldViolation {
+	field := fieldError.Namespace()
+	if index := strings.Index(field, "."); index >= 0 {
+		field = field[index+1:]
+	}
+	tag := fieldError.Tag()
+	if fieldError.Param() != "" {
+		tag = tag + "=" + fieldError.Param()
+	}
+	return errorhandler.FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf("failed on the '%s' tag", tag),
+	}
+}
+
+func isStruct(target interface{}) bool {
+	t := reflect.TypeOf(target)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	// report field names as client sent them
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, key := range []string{"json", "form", "uri", "header"} {
+			name := strings.Split(field.Tag.Get(key), ",")[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	return v
+}
+
+func renderError(c *gin.Context, err error, option *handleOption) {
+	var report errorhandler.IGinErrorReport
+	if errors.As(err, &report) {
+		report.SetSystem(option.system).Report(option.prefixMessage)
+		report.GinReport(c)
+		return
+	}
+	if option.prefixMessage != "" {
+		err = fmt.Errorf("%s: %w", option.prefixMessage, err)
+	}
+	_ = c.AbortWithError(http.StatusInternalServerError, err)
+}
@@ -0,0 +1,179 @@
+package restful
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testHandleRequest struct {
+	ID        string `uri:"id" validate:"required,uuid"`
+	Page      int64  `form:"page,default=1" validate:"min=1"`
+	RequestID string `header:"X-Request-ID"`
+	Name      string `json:"name" form:"name" validate:"required"`
+	Age       int64  `json:"age" form:"age" validate:"gte=0,lte=150"`
+}
+
+type testHandleResponse struct {
+	ID        string `json:"id"`
+	Page      int64  `json:"page"`
+	RequestID string `json:"requestID"`
+	Name      string `json:"name"`
+	Age       int64  `json:"age"`
+}
+
+func testHandleFn(ctx context.Context, req testHandleRequest) (testHandleResponse, error) {
+	return testHandleResponse{
+		ID:        req.ID,
+		Page:      req.Page,
+		RequestID: req.RequestID,
+		Name:      req.Name,
+		Age:       req.Age,
+	}, nil
+}
+
+type HandleSuite struct {
+	suite.Suite
+	id string
+}
+
+func (suite *HandleSuite) SetupSuite() {
+	gin.SetMode(gin.ReleaseMode)
+	suite.id = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+}
+
+func (suite *HandleSuite) serve(handler gin.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.POST("/users/:id", handler)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *HandleSuite) TestHandle() {
+	suite.Equal("gin.HandlerFunc", reflect.TypeOf(Handle(testHandleFn)).String())
+}
+
+func (suite *HandleSuite) TestHandleRunJSON() {
+	req := httptest.NewRequest(http.MethodPost, "/users/"+suite.id+"?page=3", strings.NewReader(`{"name":"Max","age":18}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-request-id", "request-1")
+	w := suite.serve(Handle(testHandleFn, WithStatusCode(http.StatusCreated)), req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.JSONEq(`{"id":"`+suite.id+`","page":3,"requestID":"request-1","name":"Max","age":18}`, w.Body.String())
+}
+
+func (suite *HandleSuite) TestHandleRunForm() {
+	form := url.Values{"name": {"Max"}, "age": {"20"}}
+	req := httptest.NewRequest(http.MethodPost, "/users/"+suite.id, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := suite.serve(Handle(testHandleFn), req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"id":"`+suite.id+`","page":1,"requestID":"","name":"Max","age":20}`, w.Body.String())
+}
+
+func (suite *HandleSuite) TestHandleRunPointerRequest() {
+	req := httptest.NewRequest(http.MethodPost, "/users/"+suite.id, strings.NewReader(`{"name":"Max"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := suite.serve(Handle(func(ctx context.Context, req *testHandleRequest) (*testHandleRequest, error) {
+		return req, nil
+	}), req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *HandleSuite) TestHandleRunNoContent() {
+	req := httptest.NewRequest(http.MethodPost, "/users/"+suite.id, strings.NewReader(`{"name":"Max"}`))
+	w := suite.serve(Handle(testHandleFn, WithStatusCode(http.StatusNoContent)), req)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Empty(w.Body.String())
+}
+
+func (suite *HandleSuite) TestHandleRunValidationError() {
+	req := httptest.NewRequest(http.MethodPost, "/users/not-uuid?page=0", strings.NewReader(`{"age":200}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := suite.serve(Handle(testHandleFn), req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	result := struct {
+		Message    string                        `json:"message"`
+		Violations []errorhandler.FieldViolation `json:"violations"`
+	}{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+	suite.Contains(result.Message, errorhandler.ErrInvalidArguments.Error())
+	suite.Equal([]errorhandler.FieldViolation{
+		{Field: "id", Description: "failed on the 'uuid' tag"},
+		{Field: "page", Description: "failed on the 'min=1' tag"},
+		{Field: "name", Description: "failed on the 'required' tag"},
+		{Field: "age", Description: "failed on the 'lte=150' tag"},
+	}, result.Violations)
+}
+
+func (suite *HandleSuite) TestHandleRunBindingError() {
+	req := httptest.NewRequest(http.MethodPost, "/users/"+suite.id+"?page=abc", nil)
+	w := suite.serve(Handle(testHandleFn), req)
+	suite.Equal(http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/users/"+suite.id, strings.NewReader(`{"name":`))
+	req.Header.Set("Content-Type", "application/json")
+	w = suite.serve(Handle(testHandleFn), req)
+	suite.Equal(http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/users/"+suite.id, strings.NewReader(`<name/>`))
+	req.Header.Set("Content-Type", "application/xml")
+	w = suite.serve(Handle(testHandleFn), req)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *HandleSuite) TestHandleRunErrorReport() {
+	req := httptest.NewRequest(http.MethodPost, "/users/"+suite.id, strings.NewReader(`{"name":"Max"}`))
+	w := suite.serve(Handle(func(ctx context.Context, req testHandleRequest) (testHandleResponse, error) {
+		return testHandleResponse{}, errorhandler.NewErrPermissionDeny(errors.New("got error"))
+	}, WithSystem("Mock Handle"), WithPrefixMessage("error handle")), req)
+
+	suite.Equal(http.StatusForbidden, w.Code)
+}
+
+func (suite *HandleSuite) TestHandleRunNormalError() {
+	req := httptest.NewRequest(http.MethodPost, "/users/"+suite.id, strings.NewReader(`{"name":"Max"}`))
+	w := suite.serve(Handle(func(ctx context.Context, req testHandleRequest) (testHandleResponse, error) {
+		return testHandleResponse{}, errors.New("got error")
+	}, WithPrefixMessage("error handle")), req)
+
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (suite *HandleSuite) TestBind() {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/?name=Max", nil)
+	c.Params = gin.Params{{Key: "id", Value: suite.id}}
+	req, err := Bind[testHandleRequest](c)
+	suite.NoError(err)
+	suite.Equal(suite.id, req.ID)
+	suite.Equal("Max", req.Name)
+	suite.Equal(int64(1), req.Page)
+}
+
+func (suite *HandleSuite) TestBindError() {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err := Bind[testHandleRequest](c)
+	suite.ErrorIs(err.(*errorhandler.ErrInvalidArgument).GetError(), errorhandler.ErrInvalidArguments)
+	suite.Len(err.(*errorhandler.ErrInvalidArgument).GetFieldViolations(), 2)
+}
+
+func TestHandleSuite(t *testing.T) {
+	suite.Run(t, new(HandleSuite))
+}
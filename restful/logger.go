@@ -0,0 +1,24 @@
+package restful
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/definition"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"go.opentelemetry.io/otel/propagation"
+	"go.uber.org/zap"
+)
+
+// RequestLogger method
+// stores logger, request ID of X-Request-ID header (generated when absent, echoed in response)
+// and W3C traceparent span context into request context, handler logs by zap.FromContext(c) are correlated
+func RequestLogger(logger *zap.Logger) gin.HandlerFunc {
+	propagator := propagation.TraceContext{}
+	return func(c *gin.Context) {
+		id := zapTool.NewRequestID(c.GetHeader(definition.RequestIDKey))
+		c.Header(definition.RequestIDKey, id)
+		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx = zapTool.WithRequestID(zapTool.WithContext(ctx, logger), id)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package restful
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/definition"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type RequestLoggerSuite struct {
+	suite.Suite
+	engine *gin.Engine
+	logs   *observer.ObservedLogs
+}
+
+func (suite *RequestLoggerSuite) SetupTest() {
+	core, logs := observer.New(zapcore.DebugLevel)
+	suite.logs = logs
+	suite.engine = gin.New()
+	suite.engine.ContextWithFallback = true
+	suite.engine.Use(RequestLogger(zap.New(core)))
+	suite.engine.GET("/ping", func(c *gin.Context) {
+		c.Set(definition.AuthorizationID, []byte("client"))
+		zapTool.FromContext(c).Info("handled")
+	})
+}
+
+func (suite *RequestLoggerSuite) TestRequestLogger() {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(definition.RequestIDKey, "request-1")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	suite.engine.ServeHTTP(w, req)
+
+	suite.Equal("request-1", w.Header().Get(definition.RequestIDKey))
+	suite.Equal(1, suite.logs.Len())
+	suite.Equal(map[string]interface{}{
+		"request_id": "request-1",
+		"trace_id":   "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":    "00f067aa0ba902b7",
+		"client_id":  "client",
+	}, suite.logs.All()[0].ContextMap())
+}
+
+func (suite *RequestLoggerSuite) TestRequestLoggerGenerateID() {
+	w := httptest.NewRecorder()
+	suite.engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+	id := w.Header().Get(definition.RequestIDKey)
+	suite.Len(id, 36)
+	suite.Equal(map[string]interface{}{
+		"request_id": id,
+		"client_id":  "client",
+	}, suite.logs.All()[0].ContextMap())
+}
+
+func TestRequestLoggerSuite(t *testing.T) {
+	suite.Run(t, new(RequestLoggerSuite))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/justdomepaul/toolbox/definition"
 	"github.com/justdomepaul/toolbox/errorhandler"
 	"strings"
+	"sync/atomic"
 )
 
 type GuarderValidator interface {
@@ -20,35 +21,59 @@ func NewJWTGuarder(option config.JWT, validator GuarderValidator) *JWTGuarder {
 }
 
 type JWTGuarder struct {
-	option    config.JWT
-	validator GuarderValidator
+	option       config.JWT
+	validator    GuarderValidator
+	allowedPaths atomic.Pointer[[]string]
+}
+
+// SetAllowedPaths method
+// overrides whitelist of guarders created by JWTGuarder at runtime, e.g. on config.Watcher change
+func (j *JWTGuarder) SetAllowedPaths(paths []string) {
+	j.allowedPaths.Store(&paths)
 }
 
 // JWTGuarder method
+// panics with errorhandler error when failed, recovered by errorhandler.GinPanicErrorHandler
 func (j *JWTGuarder) JWTGuarder(whitelist ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// skip if matching whitelist
-		for _, term := range whitelist {
-			if strings.HasPrefix(c.FullPath(), term) || strings.HasPrefix(c.Request.RequestURI, term) {
-				c.Next()
-				return
-			}
+		if err := j.guard(c, whitelist); err != nil {
+			panic(err)
 		}
-		token := c.DefaultQuery(definition.QueryAuthKey, "")
-		if token == "" {
-			authorization := c.GetHeader(definition.AuthorizationKey)
-			if len(authorization) == 0 {
-				panic(errorhandler.NewErrInvalidArgument(errorhandler.ErrAuthorizationRequired))
-			}
-			if strings.HasPrefix(authorization, definition.AuthorizationType) {
-				token = strings.TrimPrefix(authorization, definition.AuthorizationType)
-			} else {
-				panic(errorhandler.NewErrInvalidArgument(errorhandler.ErrAuthorizationTypeBearer))
-			}
+	}
+}
+
+// Guard method
+// records errorhandler error by c.Error and aborts when failed, rendered by errorhandler.GinErrorHandler
+func (j *JWTGuarder) Guard(whitelist ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := j.guard(c, whitelist); err != nil {
+			_ = c.Error(err)
+			c.Abort()
 		}
+	}
+}
 
-		if err := j.validator.Verify(c, token); err != nil {
-			panic(err)
+func (j *JWTGuarder) guard(c *gin.Context, whitelist []string) error {
+	paths := whitelist
+	if allowedPaths := j.allowedPaths.Load(); allowedPaths != nil {
+		paths = *allowedPaths
+	}
+	// skip if matching whitelist
+	for _, term := range paths {
+		if strings.HasPrefix(c.FullPath(), term) || strings.HasPrefix(c.Request.RequestURI, term) {
+			return nil
+		}
+	}
+	token := c.DefaultQuery(definition.QueryAuthKey, "")
+	if token == "" {
+		authorization := c.GetHeader(definition.AuthorizationKey)
+		if len(authorization) == 0 {
+			return errorhandler.NewErrInvalidArgument(errorhandler.ErrAuthorizationRequired)
+		}
+		if !strings.HasPrefix(authorization, definition.AuthorizationType) {
+			return errorhandler.NewErrInvalidArgument(errorhandler.ErrAuthorizationTypeBearer)
 		}
+		token = strings.TrimPrefix(authorization, definition.AuthorizationType)
 	}
+	return j.validator.Verify(c, token)
 }
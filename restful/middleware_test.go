@@ -173,6 +173,56 @@ func (suite *MiddlewareSuite) TestJWTGuarderRunPermissionNotAllow() {
 	suite.Equal(http.StatusForbidden, w.Code)
 }
 
+func (suite *MiddlewareSuite) TestJWTGuarderSetAllowedPaths() {
+	testGuarderValidator := &testGuarderValidator{}
+	testGuarderValidator.On("Verify", mock.Anything, mock.Anything).Return(nil)
+	guarder := NewJWTGuarder(suite.jwtOp, testGuarderValidator)
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(errorhandler.GinPanicErrorHandler("Mock Gin", "error Gin mock"))
+	r.GET("/ping", guarder.JWTGuarder("/ping"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func() int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		return w.Code
+	}
+	suite.Equal(http.StatusOK, request())
+	guarder.SetAllowedPaths([]string{"/metrics"})
+	suite.Equal(http.StatusBadRequest, request())
+	guarder.SetAllowedPaths([]string{"/metrics", "/ping"})
+	suite.Equal(http.StatusOK, request())
+}
+
+func (suite *MiddlewareSuite) TestGuard() {
+	testGuarderValidator := &testGuarderValidator{}
+	testGuarderValidator.On("Verify", mock.Anything, "allow").Return(nil)
+	testGuarderValidator.On("Verify", mock.Anything, "deny").Return(errorhandler.NewErrPermissionDeny(errors.New("got error")))
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(errorhandler.GinErrorHandler("Mock Gin", "error Gin mock"))
+	r.GET("/ping", NewJWTGuarder(suite.jwtOp, testGuarderValidator).Guard(), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	for authorization, code := range map[string]int{
+		"":             http.StatusBadRequest,
+		"Basic allow":  http.StatusBadRequest,
+		"Bearer deny":  http.StatusForbidden,
+		"Bearer allow": http.StatusNoContent,
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("Authorization", authorization)
+		suite.NotPanics(func() {
+			r.ServeHTTP(w, req)
+		})
+		suite.Equal(code, w.Code, authorization)
+	}
+}
+
 func TestMiddlewareSuite(t *testing.T) {
 	suite.Run(t, new(MiddlewareSuite))
 }
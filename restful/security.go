@@ -0,0 +1,45 @@
+package restful
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/config"
+	"strconv"
+)
+
+const (
+	HeaderStrictTransportSecurity = "Strict-Transport-Security"
+	HeaderContentSecurityPolicy   = "Content-Security-Policy"
+	HeaderFrameOptions            = "X-Frame-Options"
+	HeaderReferrerPolicy          = "Referrer-Policy"
+	HeaderContentTypeOptions      = "X-Content-Type-Options"
+)
+
+// SecurityHeaders method
+// writes HSTS, CSP, X-Frame-Options, Referrer-Policy and X-Content-Type-Options response headers,
+// empty option value skip the related header
+func SecurityHeaders(option config.Server) gin.HandlerFunc {
+	hsts := ""
+	if option.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.FormatInt(int64(option.HSTSMaxAge.Seconds()), 10)
+		if option.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		if hsts != "" {
+			header.Set(HeaderStrictTransportSecurity, hsts)
+		}
+		if option.ContentSecurityPolicy != "" {
+			header.Set(HeaderContentSecurityPolicy, option.ContentSecurityPolicy)
+		}
+		if option.FrameOptions != "" {
+			header.Set(HeaderFrameOptions, option.FrameOptions)
+		}
+		if option.ReferrerPolicy != "" {
+			header.Set(HeaderReferrerPolicy, option.ReferrerPolicy)
+		}
+		header.Set(HeaderContentTypeOptions, "nosniff")
+		c.Next()
+	}
+}
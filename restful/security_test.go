@@ -0,0 +1,62 @@
+package restful
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type SecurityHeadersSuite struct {
+	suite.Suite
+}
+
+func (suite *SecurityHeadersSuite) TestSecurityHeaders() {
+	suite.Equal("gin.HandlerFunc", reflect.TypeOf(SecurityHeaders(config.Server{})).String())
+}
+
+func (suite *SecurityHeadersSuite) TestSecurityHeadersRun() {
+	r := gin.New()
+	r.Use(SecurityHeaders(config.Server{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		ContentSecurityPolicy: "default-src 'self'",
+		FrameOptions:          "SAMEORIGIN",
+		ReferrerPolicy:        "no-referrer",
+	}))
+	r.GET("/ping", QuickReply())
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("max-age=31536000; includeSubDomains", w.Header().Get(HeaderStrictTransportSecurity))
+	suite.Equal("default-src 'self'", w.Header().Get(HeaderContentSecurityPolicy))
+	suite.Equal("SAMEORIGIN", w.Header().Get(HeaderFrameOptions))
+	suite.Equal("no-referrer", w.Header().Get(HeaderReferrerPolicy))
+	suite.Equal("nosniff", w.Header().Get(HeaderContentTypeOptions))
+}
+
+func (suite *SecurityHeadersSuite) TestSecurityHeadersRunEmptyOption() {
+	r := gin.New()
+	r.Use(SecurityHeaders(config.Server{}))
+	r.GET("/ping", QuickReply())
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Empty(w.Header().Get(HeaderStrictTransportSecurity))
+	suite.Empty(w.Header().Get(HeaderContentSecurityPolicy))
+	suite.Empty(w.Header().Get(HeaderFrameOptions))
+	suite.Empty(w.Header().Get(HeaderReferrerPolicy))
+	suite.Equal("nosniff", w.Header().Get(HeaderContentTypeOptions))
+}
+
+func TestSecurityHeadersSuite(t *testing.T) {
+	suite.Run(t, new(SecurityHeadersSuite))
+}
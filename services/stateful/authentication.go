@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"github.com/justdomepaul/toolbox/array"
+	"github.com/justdomepaul/toolbox/audit"
 	"github.com/justdomepaul/toolbox/config"
 	"github.com/justdomepaul/toolbox/errorhandler"
 	"github.com/justdomepaul/toolbox/jwt"
 	"github.com/justdomepaul/toolbox/services"
+	zapTool "github.com/justdomepaul/toolbox/zap"
 	"strings"
+	"sync/atomic"
 )
 
 var (
@@ -36,36 +39,82 @@ func (a *Authorization) GetClaim() interface{} {
 }
 
 func NewAuthentication(gRPC config.GRPC, jwt jwt.IJWT) (*Authentication, error) {
-	return &Authentication{
-		allowedList: gRPC.AllowedList,
-		j:           jwt,
-	}, nil
+	a := &Authentication{
+		j: jwt,
+	}
+	a.SetAllowedList(gRPC.AllowedList)
+	return a, nil
 }
 
 type Authentication struct {
-	allowedList []string
+	allowedList atomic.Pointer[[]string]
 	j           jwt.IJWT
+	auditor     audit.IAuditor
+}
+
+// SetAuditor method
+// records authentication decisions, set before serving
+func (s *Authentication) SetAuditor(auditor audit.IAuditor) {
+	s.auditor = auditor
+}
+
+// SetAllowedList method
+// replaces methods skipping authentication at runtime, e.g. on config.Watcher change
+func (s *Authentication) SetAllowedList(allowedList []string) {
+	s.allowedList.Store(&allowedList)
+}
+
+// AllowedList method
+func (s *Authentication) AllowedList() []string {
+	if allowedList := s.allowedList.Load(); allowedList != nil {
+		return *allowedList
+	}
+	return nil
 }
 
 func (s *Authentication) Authenticate(ctx context.Context, tokenFn func() (string, error), fullMethod string) (authorization services.IAuthorization, err error) {
-	for _, term := range s.allowedList {
+	for _, term := range s.AllowedList() {
 		if strings.HasPrefix(fullMethod, term) {
+			s.record(ctx, audit.Event{Method: fullMethod, Decision: audit.DecisionSkip, Reason: errorhandler.ErrInWhitelist.Error()})
 			return NewAuthorization(nil, nil), errorhandler.ErrInWhitelist
 		}
 	}
 	token, err := tokenFn()
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", errorhandler.ErrUnauthenticated, err.Error())
+		err = fmt.Errorf("%w: %s", errorhandler.ErrUnauthenticated, err.Error())
+		s.record(ctx, audit.Event{Method: fullMethod, Decision: audit.DecisionDeny, Reason: err.Error()})
+		return nil, err
 	}
 
 	claim := newToken(jwt.NewClaimsBuilder().Build())
 	if err := s.j.VerifyToken(token, claim); err != nil {
-		return nil, fmt.Errorf("%w: %s", errorhandler.ErrUnauthenticated, err.Error())
+		err = fmt.Errorf("%w: %s", errorhandler.ErrUnauthenticated, err.Error())
+		s.record(ctx, audit.Event{Method: fullMethod, Decision: audit.DecisionDeny, Reason: err.Error()})
+		return nil, err
 	}
 
+	event := audit.Event{Method: fullMethod, ClientID: zapTool.FormatClientID(claim.ClientID)}
+	if claim.Claims != nil {
+		event.TokenID = claim.ID
+	}
 	if _, exist := array.Find(claim.Scopes, fullMethod); !exist {
+		event.Decision, event.Reason = audit.DecisionDeny, errorhandler.ErrOutOfScopes.Error()
+		s.record(ctx, event)
 		return nil, errorhandler.ErrOutOfScopes
 	}
 
+	event.Decision = audit.DecisionAllow
+	s.record(ctx, event)
 	return NewAuthorization(claim.ClientID, claim), nil
 }
+
+func (s *Authentication) record(ctx context.Context, event audit.Event) {
+	if s.auditor == nil {
+		return
+	}
+	event.SourceIP = audit.PeerIP(ctx)
+	if ctx != nil {
+		event.RequestID = zapTool.RequestID(ctx)
+	}
+	s.auditor.Record(event)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
+	"github.com/justdomepaul/toolbox/audit"
 	"github.com/justdomepaul/toolbox/config"
 	"github.com/justdomepaul/toolbox/errorhandler"
 	"github.com/justdomepaul/toolbox/jwt"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/peer"
+	"net"
 	"reflect"
 	"testing"
 )
@@ -139,6 +142,55 @@ func (suite *CommonAuthenticationSuite) TestAuthenticateMethodOutOfScopes() {
 	suite.Empty(resultID)
 }
 
+func (suite *CommonAuthenticationSuite) TestSetAllowedList() {
+	service, err := NewAuthentication(config.GRPC{AllowedList: []string{"/auth.Auth/Ping"}}, suite.jwt)
+	suite.NoError(err)
+	suite.Equal([]string{"/auth.Auth/Ping"}, service.AllowedList())
+
+	_, err = service.Authenticate(context.Background(), func() (string, error) { return "", nil }, "/auth.Auth/Refresh")
+	suite.ErrorIs(err, errorhandler.ErrUnauthenticated)
+
+	service.SetAllowedList([]string{"/auth.Auth/Refresh"})
+	suite.Equal([]string{"/auth.Auth/Refresh"}, service.AllowedList())
+	_, err = service.Authenticate(context.Background(), func() (string, error) { return "", nil }, "/auth.Auth/Refresh")
+	suite.ErrorIs(err, errorhandler.ErrInWhitelist)
+}
+
+func (suite *CommonAuthenticationSuite) TestSetAuditor() {
+	service, err := NewAuthentication(config.GRPC{AllowedList: []string{"/ping"}}, suite.jwt)
+	suite.NoError(err)
+	auditor := &testAuditor{}
+	service.SetAuditor(auditor)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+	_, err = service.Authenticate(ctx, func() (string, error) { return suite.token, nil }, "/ping")
+	suite.ErrorIs(err, errorhandler.ErrInWhitelist)
+	_, err = service.Authenticate(ctx, func() (string, error) { return "", errors.New("no token") }, "/pong")
+	suite.ErrorIs(err, errorhandler.ErrUnauthenticated)
+	_, err = service.Authenticate(ctx, func() (string, error) { return "token", nil }, "/pong")
+	suite.ErrorIs(err, errorhandler.ErrUnauthenticated)
+	_, err = service.Authenticate(ctx, func() (string, error) { return suite.token, nil }, "/foo")
+	suite.ErrorIs(err, errorhandler.ErrOutOfScopes)
+	_, err = service.Authenticate(nil, func() (string, error) { return suite.token, nil }, "/pong")
+	suite.NoError(err)
+
+	suite.Len(auditor.events, 5)
+	suite.Equal(audit.Event{Method: "/ping", Decision: audit.DecisionSkip, Reason: errorhandler.ErrInWhitelist.Error(), SourceIP: "10.0.0.1"}, auditor.events[0])
+	suite.Equal(audit.Event{Method: "/pong", Decision: audit.DecisionDeny, Reason: "unauthenticated: no token", SourceIP: "10.0.0.1"}, auditor.events[1])
+	suite.Equal(audit.DecisionDeny, auditor.events[2].Decision)
+	suite.Empty(auditor.events[2].ClientID)
+	suite.Equal(audit.Event{Method: "/foo", ClientID: suite.uid.String(), Decision: audit.DecisionDeny, Reason: errorhandler.ErrOutOfScopes.Error(), SourceIP: "10.0.0.1"}, auditor.events[3])
+	suite.Equal(audit.Event{Method: "/pong", ClientID: suite.uid.String(), Decision: audit.DecisionAllow}, auditor.events[4])
+}
+
 func TestCommonAuthenticationSuite(t *testing.T) {
 	suite.Run(t, new(CommonAuthenticationSuite))
 }
+
+type testAuditor struct {
+	events []audit.Event
+}
+
+func (t *testAuditor) Record(event audit.Event) {
+	t.events = append(t.events, event)
+}
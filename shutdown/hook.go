@@ -0,0 +1,206 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"go.uber.org/zap"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrHookTimeout hook not finished before its deadline
+	ErrHookTimeout = errors.New("shutdown hook timeout")
+)
+
+// hook status
+const (
+	HookSucceeded = "succeeded"
+	HookFailed    = "failed"
+	HookTimeout   = "timeout"
+)
+
+// Hook type
+type Hook struct {
+	Name     string
+	Priority int
+	Timeout  time.Duration
+	Fn       func(ctx context.Context) error
+}
+
+// HookResult type
+type HookResult struct {
+	Name     string
+	Priority int
+	Status   string
+	Err      error
+	Elapsed  time.Duration
+}
+
+// WithHook method
+func WithHook(name string, priority int, fn func(ctx context.Context) error) Option {
+	return withHook{hook: Hook{Name: name, Priority: priority, Fn: fn}}
+}
+
+type withHook struct {
+	hook Hook
+}
+
+// Apply method
+func (w withHook) Apply(c *Shutdown) {
+	c.hooks = append(c.hooks, w.hook)
+}
+
+// WithHookTimeout method
+// default deadline of each hook, serverTimeout used when not set
+func WithHookTimeout(duration time.Duration) Option {
+	return withHookTimeout{timeout: duration}
+}
+
+type withHookTimeout struct {
+	timeout time.Duration
+}
+
+// Apply method
+func (w withHookTimeout) Apply(c *Shutdown) {
+	c.hookTimeout = w.timeout
+}
+
+// Register method
+// hooks run after servers stopped, in ascending priority phases,
+// hooks with the same priority run concurrently
+func (s *Shutdown) Register(name string, priority int, fn func(ctx context.Context) error) {
+	s.RegisterHook(Hook{Name: name, Priority: priority, Fn: fn})
+}
+
+// RegisterHook method
+// Hook.Timeout overrides WithHookTimeout
+func (s *Shutdown) RegisterHook(hook Hook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, hook)
+}
+
+// Results method
+// returns hook results after Shutdown completed
+func (s *Shutdown) Results() []HookResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.results
+}
+
+func call(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
+
+func (s *Shutdown) runHooks() error {
+	s.mu.Lock()
+	hooks := make([]Hook, len(s.hooks))
+	copy(hooks, s.hooks)
+	s.mu.Unlock()
+	if len(hooks) == 0 {
+		return nil
+	}
+	sort.SliceStable(hooks, func(i, j int) bool {
+		return hooks[i].Priority < hooks[j].Priority
+	})
+
+	results := make([]HookResult, len(hooks))
+	for start := 0; start < len(hooks); {
+		end := start
+		for end < len(hooks) && hooks[end].Priority == hooks[start].Priority {
+			end++
+		}
+		wg := &sync.WaitGroup{}
+		for i := start; i < end; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i] = s.runHook(hooks[i])
+			}(i)
+		}
+		wg.Wait()
+		start = end
+	}
+
+	s.mu.Lock()
+	s.results = results
+	s.mu.Unlock()
+	return summarize(results)
+}
+
+func (s *Shutdown) runHook(hook Hook) HookResult {
+	timeout := hook.Timeout
+	if timeout == 0 {
+		timeout = s.hookTimeout
+	}
+	if timeout == 0 {
+		timeout = s.serverTimeout
+	}
+	ctx, cancel := context.Background(), func() {}
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	result := HookResult{Name: hook.Name, Priority: hook.Priority}
+	start := time.Now()
+	finished := make(chan error, 1)
+	go func() {
+		finished <- call(ctx, hook.Fn)
+	}()
+	select {
+	case err := <-finished:
+		result.Err = err
+	case <-ctx.Done():
+		result.Err = ErrHookTimeout
+	}
+	result.Elapsed = time.Since(start)
+
+	switch {
+	case result.Err == nil:
+		result.Status = HookSucceeded
+	case errors.Is(result.Err, ErrHookTimeout), errors.Is(result.Err, context.DeadlineExceeded):
+		result.Status = HookTimeout
+	default:
+		result.Status = HookFailed
+	}
+	return result
+}
+
+func summarize(results []HookResult) error {
+	succeeded, failed, timeout := make([]string, 0), make([]string, 0), make([]string, 0)
+	errs := make([]error, 0)
+	for _, result := range results {
+		switch result.Status {
+		case HookSucceeded:
+			succeeded = append(succeeded, result.Name)
+			continue
+		case HookTimeout:
+			timeout = append(timeout, result.Name)
+		default:
+			failed = append(failed, result.Name)
+		}
+		errs = append(errs, fmt.Errorf("shutdown hook %s: %w", result.Name, result.Err))
+	}
+	fields := []zap.Field{
+		zap.String("system", "Shutdown"),
+		zap.Strings("succeeded", succeeded),
+		zap.Strings("failed", failed),
+		zap.Strings("timeout", timeout),
+	}
+	if len(errs) > 0 {
+		zapTool.Logger.Warn("shutdown hooks finished with error", fields...)
+		return errors.Join(errs...)
+	}
+	zapTool.Logger.Info("shutdown hooks finished", fields...)
+	return nil
+}
@@ -0,0 +1,116 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type HookSuite struct {
+	suite.Suite
+}
+
+func (suite *HookSuite) TestWithHookOption() {
+	s := NewShutdown(WithHook("db", 1, func(ctx context.Context) error { return nil }))
+	suite.Len(s.hooks, 1)
+	suite.Equal("db", s.hooks[0].Name)
+	suite.Equal(1, s.hooks[0].Priority)
+}
+
+func (suite *HookSuite) TestWithHookTimeoutOption() {
+	suite.Equal(time.Second, NewShutdown(WithHookTimeout(time.Second)).hookTimeout)
+}
+
+func (suite *HookSuite) TestRegister() {
+	s := NewShutdown()
+	s.Register("db", 1, func(ctx context.Context) error { return nil })
+	s.RegisterHook(Hook{Name: "cache", Priority: 2, Timeout: time.Second, Fn: func(ctx context.Context) error { return nil }})
+	suite.Len(s.hooks, 2)
+	suite.Equal(time.Second, s.hooks[1].Timeout)
+}
+
+func (suite *HookSuite) TestRunHooksOrder() {
+	s := NewShutdown()
+	mu := sync.Mutex{}
+	order := make([]string, 0)
+	record := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+	s.Register("last", 10, record("last"))
+	s.Register("first", 0, record("first"))
+	s.Register("middle", 5, record("middle"))
+	suite.NoError(s.runHooks())
+	suite.Equal([]string{"first", "middle", "last"}, order)
+	for _, result := range s.Results() {
+		suite.Equal(HookSucceeded, result.Status)
+	}
+}
+
+func (suite *HookSuite) TestRunHooksSamePriorityConcurrently() {
+	s := NewShutdown(WithHookTimeout(time.Second))
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	wait := func(ctx context.Context) error {
+		wg.Done()
+		wg.Wait()
+		return nil
+	}
+	s.Register("a", 1, wait)
+	s.Register("b", 1, wait)
+	suite.NoError(s.runHooks())
+}
+
+func (suite *HookSuite) TestRunHooksError() {
+	s := NewShutdown(WithHookTimeout(50 * time.Millisecond))
+	s.Register("ok", 0, func(ctx context.Context) error { return nil })
+	s.Register("failed", 0, func(ctx context.Context) error { return errors.New("got error") })
+	s.Register("panic", 0, func(ctx context.Context) error { panic("got panic") })
+	s.Register("timeout", 1, func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+	err := s.runHooks()
+	suite.Error(err)
+	suite.ErrorIs(err, ErrHookTimeout)
+	suite.Contains(err.Error(), "shutdown hook failed: got error")
+	suite.Contains(err.Error(), "shutdown hook panic: panic: got panic")
+
+	status := make(map[string]string)
+	for _, result := range s.Results() {
+		status[result.Name] = result.Status
+	}
+	suite.Equal(map[string]string{
+		"ok":      HookSucceeded,
+		"failed":  HookFailed,
+		"panic":   HookFailed,
+		"timeout": HookTimeout,
+	}, status)
+}
+
+func TestHookSuite(t *testing.T) {
+	suite.Run(t, new(HookSuite))
+}
+
+func Test_ShutdownResult(t *testing.T) {
+	quit := make(chan os.Signal, 1)
+	done := make(chan error, 1)
+	s := NewShutdown(
+		WithQuit(quit),
+		WithDone(done),
+		WithHook("failed", 0, func(ctx context.Context) error { return errors.New("got error") }),
+	)
+	go s.Shutdown()
+	quit <- syscall.SIGTERM
+	require.EqualError(t, <-done, "shutdown hook failed: got error")
+}
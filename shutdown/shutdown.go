@@ -1,14 +1,26 @@
 package shutdown
 
 import (
+	"context"
+	"github.com/cockroachdb/errors"
+	"github.com/justdomepaul/toolbox/config"
 	zapTool "github.com/justdomepaul/toolbox/zap"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
 
+var (
+	listen = net.Listen
+)
+
 // Option interface
 type Option interface {
 	Apply(*Shutdown)
@@ -29,12 +41,13 @@ func (w withQuit) Apply(c *Shutdown) {
 }
 
 // WithDone method
-func WithDone(done chan bool) Option {
+// receives aggregated hook error (nil when all hooks succeeded) after shutdown completed
+func WithDone(done chan error) Option {
 	return withDone{done: done}
 }
 
 type withDone struct {
-	done chan bool
+	done chan error
 }
 
 // Apply method
@@ -70,29 +83,265 @@ func (w withEndTask) Apply(c *Shutdown) {
 	c.endTask = w.fn
 }
 
+// WithHTTPServer method
+// serves handler (e.g. *gin.Engine) on option.Port, option.ServerTimeout used when WithServerTimeout not set
+func WithHTTPServer(handler http.Handler, option config.Server) Option {
+	return withHTTPServer{handler: handler, option: option}
+}
+
+type withHTTPServer struct {
+	handler http.Handler
+	option  config.Server
+}
+
+// Apply method
+func (w withHTTPServer) Apply(c *Shutdown) {
+	c.httpServer = &http.Server{
+		Addr:              ":" + w.option.Port,
+		Handler:           w.handler,
+		ReadHeaderTimeout: w.option.ServerTimeout,
+	}
+	if c.serverTimeout == 0 {
+		c.serverTimeout = w.option.ServerTimeout
+	}
+}
+
+// WithGRPCServer method
+// serves server on option.Port
+func WithGRPCServer(server *grpc.Server, option config.GRPC) Option {
+	return withGRPCServer{server: server, option: option}
+}
+
+type withGRPCServer struct {
+	server *grpc.Server
+	option config.GRPC
+}
+
+// Apply method
+func (w withGRPCServer) Apply(c *Shutdown) {
+	c.grpcServer = w.server
+	c.grpcAddr = ":" + w.option.Port
+}
+
+// WithCleanup method
+// cleanup functions (e.g. wire cleanup) run in reverse registration order after servers stopped,
+// use Register for NewExtend*Database cleanup to report its error
+func WithCleanup(fns ...func()) Option {
+	return withCleanup{fns: fns}
+}
+
+type withCleanup struct {
+	fns []func()
+}
+
+// Apply method
+func (w withCleanup) Apply(c *Shutdown) {
+	c.cleanups = append(c.cleanups, w.fns...)
+}
+
+// WithReadinessDelay method
+// waits duration between readiness failing and servers draining, let load balancer stop routing
+func WithReadinessDelay(duration time.Duration) Option {
+	return withReadinessDelay{delay: duration}
+}
+
+type withReadinessDelay struct {
+	delay time.Duration
+}
+
+// Apply method
+func (w withReadinessDelay) Apply(c *Shutdown) {
+	c.readinessDelay = w.delay
+}
+
 // Shutdown type
 type Shutdown struct {
-	quit          chan os.Signal
-	done          chan bool
-	serverTimeout time.Duration
-	endTask       func()
+	quit           chan os.Signal
+	done           chan error
+	serverTimeout  time.Duration
+	readinessDelay time.Duration
+	endTask        func()
+	httpServer     *http.Server
+	httpListenAddr net.Addr
+	grpcServer     *grpc.Server
+	grpcAddr       string
+	grpcListenAddr net.Addr
+	cleanups       []func()
+	hooks          []Hook
+	hookTimeout    time.Duration
+	results        []HookResult
+	failed         chan error
+	ready          atomic.Bool
+	mu             sync.Mutex
+}
+
+// AddCleanup method
+func (s *Shutdown) AddCleanup(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cleanups = append(s.cleanups, fn)
+}
+
+// Start method
+// listens and serves HTTP and gRPC servers in background then marks ready,
+// listen or serve failure triggers Shutdown, servers already started are stopped by Shutdown
+func (s *Shutdown) Start() error {
+	if s.httpServer != nil {
+		lis, err := listen("tcp", s.httpServer.Addr)
+		if err != nil {
+			err = errors.Wrap(err, "listen HTTP server")
+			s.trigger(err)
+			return err
+		}
+		s.httpListenAddr = lis.Addr()
+		go func() {
+			if err := s.httpServer.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				s.serveFailed("HTTP", err)
+			}
+		}()
+		zapTool.Logger.Info("HTTP server listening", zap.String("system", "Shutdown"), zap.Stringer("addr", s.httpListenAddr))
+	}
+	if s.grpcServer != nil {
+		lis, err := listen("tcp", s.grpcAddr)
+		if err != nil {
+			err = errors.Wrap(err, "listen gRPC server")
+			s.trigger(err)
+			return err
+		}
+		s.grpcListenAddr = lis.Addr()
+		go func() {
+			if err := s.grpcServer.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+				s.serveFailed("gRPC", err)
+			}
+		}()
+		zapTool.Logger.Info("gRPC server listening", zap.String("system", "Shutdown"), zap.Stringer("addr", s.grpcListenAddr))
+	}
+	s.SetReady(true)
+	return nil
+}
+
+// serveFailed triggers Shutdown
+func (s *Shutdown) serveFailed(server string, err error) {
+	zapTool.Logger.Error(server+" server serve failed", zap.String("system", "Shutdown"), zap.Error(err))
+	s.trigger(err)
+}
+
+// trigger triggers Shutdown by failed channel, quit may be full or unbuffered,
+// failed keeps the first failure only
+func (s *Shutdown) trigger(err error) {
+	select {
+	case s.failed <- err:
+	default:
+	}
+}
+
+// HTTPAddr method
+// returns HTTP listening address after Start
+func (s *Shutdown) HTTPAddr() net.Addr {
+	return s.httpListenAddr
+}
+
+// GRPCAddr method
+// returns gRPC listening address after Start
+func (s *Shutdown) GRPCAddr() net.Addr {
+	return s.grpcListenAddr
+}
+
+// SetReady method
+func (s *Shutdown) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+// Ready method
+func (s *Shutdown) Ready() bool {
+	return s.ready.Load()
+}
+
+// ReadinessHandler method
+// responds 200 when ready, 503 after shutdown started
+func (s *Shutdown) ReadinessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("shutting down"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}
 }
 
 // Shutdown method
-func (s Shutdown) Shutdown() {
+func (s *Shutdown) Shutdown() {
 	// Wait for interrupt signal to gracefully shutdown the server with
-	// a timeout of 5 seconds.
+	// a timeout of serverTimeout.
 	signal.Notify(s.quit, syscall.SIGINT, syscall.SIGTERM)
-	<-s.quit
+	select {
+	case <-s.quit:
+	case <-s.failed:
+	}
+	signal.Stop(s.quit)
 	zapTool.Logger.Info("Start Shutdown server ...")
 
+	s.SetReady(false)
+	if s.readinessDelay > 0 && (s.httpServer != nil || s.grpcServer != nil) {
+		time.Sleep(s.readinessDelay)
+	}
+	s.stopServers()
+
 	if s.endTask != nil {
 		zapTool.Logger.Warn("shutdown endTask ...", zap.String("system", "Shutdown"))
 		s.endTask()
 	}
+	err := s.runHooks()
+	s.runCleanups()
 	zapTool.Logger.Info("system Successfully Stop", zap.String("system", "Shutdown"))
 	if s.done != nil {
-		s.done <- true
+		s.done <- err
+	}
+}
+
+func (s *Shutdown) stopServers() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.serverTimeout)
+	defer cancel()
+
+	wg := &sync.WaitGroup{}
+	if s.httpServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.httpServer.Shutdown(ctx); err != nil {
+				zapTool.Logger.Warn("HTTP server shutdown timeout, force close", zap.String("system", "Shutdown"), zap.Error(err))
+				_ = s.httpServer.Close()
+			}
+		}()
+	}
+	if s.grpcServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stopped := make(chan struct{})
+			go func() {
+				s.grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				zapTool.Logger.Warn("gRPC server graceful stop timeout, force stop", zap.String("system", "Shutdown"))
+				s.grpcServer.Stop()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *Shutdown) runCleanups() {
+	s.mu.Lock()
+	cleanups := s.cleanups
+	s.mu.Unlock()
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
 	}
 }
 
@@ -104,8 +353,9 @@ func NewShutdown(options ...Option) *Shutdown {
 	}
 
 	if shutdown.quit == nil {
-		shutdown.quit = make(chan os.Signal)
+		shutdown.quit = make(chan os.Signal, 1)
 	}
+	shutdown.failed = make(chan error, 1)
 	if shutdown.serverTimeout == 0 {
 		shutdown.serverTimeout = 5 * time.Second
 	}
@@ -1,7 +1,15 @@
 package shutdown
 
 import (
+	"github.com/cockroachdb/errors"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"syscall"
@@ -18,7 +26,7 @@ func (suite *shutdown) TestWithQuitOption() {
 }
 
 func (suite *shutdown) TestWithDoneOption() {
-	suite.Equal("chan bool", reflect.TypeOf(NewShutdown(WithDone(make(chan bool))).done).String())
+	suite.Equal("chan error", reflect.TypeOf(NewShutdown(WithDone(make(chan error))).done).String())
 }
 
 func (suite *shutdown) TestWithServerTimeoutOption() {
@@ -37,7 +45,7 @@ func Test_Shutdown(t *testing.T) {
 	t.Run("test Shutdown", func(t *testing.T) {
 		quit := make(chan os.Signal)
 		defer close(quit)
-		done := make(chan bool)
+		done := make(chan error)
 		defer close(done)
 
 		go func() {
@@ -53,3 +61,118 @@ func Test_Shutdown(t *testing.T) {
 		<-done
 	})
 }
+
+func (suite *shutdown) TestWithHTTPServerOption() {
+	s := NewShutdown(WithHTTPServer(http.NotFoundHandler(), config.Server{Port: "0", ServerTimeout: 3 * time.Second}))
+	suite.Equal(":0", s.httpServer.Addr)
+	suite.Equal(3*time.Second, s.serverTimeout)
+
+	s = NewShutdown(WithServerTimeout(time.Second), WithHTTPServer(http.NotFoundHandler(), config.Server{Port: "0", ServerTimeout: 3 * time.Second}))
+	suite.Equal(time.Second, s.serverTimeout)
+}
+
+func (suite *shutdown) TestWithGRPCServerOption() {
+	s := NewShutdown(WithGRPCServer(grpc.NewServer(), config.GRPC{Port: "0"}))
+	suite.Equal("*grpc.Server", reflect.TypeOf(s.grpcServer).String())
+	suite.Equal(":0", s.grpcAddr)
+}
+
+func (suite *shutdown) TestWithCleanupOption() {
+	s := NewShutdown(WithCleanup(func() {}, func() {}), WithCleanup(func() {}))
+	suite.Len(s.cleanups, 3)
+	s.AddCleanup(func() {})
+	suite.Len(s.cleanups, 4)
+}
+
+func (suite *shutdown) TestWithReadinessDelayOption() {
+	suite.Equal(time.Second, NewShutdown(WithReadinessDelay(time.Second)).readinessDelay)
+}
+
+func (suite *shutdown) TestReadinessHandler() {
+	s := NewShutdown()
+	w := httptest.NewRecorder()
+	s.ReadinessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	suite.Equal(http.StatusServiceUnavailable, w.Code)
+
+	s.SetReady(true)
+	w = httptest.NewRecorder()
+	s.ReadinessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *shutdown) TestStartListenError() {
+	defer gostub.StubFunc(&listen, nil, errors.New("got error")).Reset()
+	suite.Error(NewShutdown(WithHTTPServer(http.NotFoundHandler(), config.Server{Port: "0"})).Start())
+	suite.Error(NewShutdown(WithGRPCServer(grpc.NewServer(), config.GRPC{Port: "0"})).Start())
+
+	done := make(chan error, 1)
+	s := NewShutdown(WithDone(done), WithHTTPServer(http.NotFoundHandler(), config.Server{Port: "0"}))
+	suite.Error(s.Start())
+	// listen failure triggers Shutdown
+	s.Shutdown()
+	suite.NoError(<-done)
+}
+
+func Test_ShutdownServers(t *testing.T) {
+	quit := make(chan os.Signal, 1)
+	done := make(chan error)
+	order := make([]string, 0)
+	s := NewShutdown(
+		WithQuit(quit),
+		WithDone(done),
+		WithHTTPServer(http.NotFoundHandler(), config.Server{Port: "0", ServerTimeout: time.Second}),
+		WithGRPCServer(grpc.NewServer(), config.GRPC{Port: "0"}),
+		WithCleanup(
+			func() { order = append(order, "first") },
+			func() { order = append(order, "second") },
+		),
+	)
+	require.NoError(t, s.Start())
+	require.True(t, s.Ready())
+
+	resp, err := http.Get("http://" + s.HTTPAddr().String())
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	go s.Shutdown()
+	quit <- syscall.SIGTERM
+	<-done
+
+	require.False(t, s.Ready())
+	require.Equal(t, []string{"second", "first"}, order)
+	_, err = http.Get("http://" + s.HTTPAddr().String())
+	require.Error(t, err)
+	_, err = net.Dial("tcp", s.GRPCAddr().String())
+	require.Error(t, err)
+}
+
+type failedListener struct {
+	net.Listener
+}
+
+func (l failedListener) Accept() (net.Conn, error) {
+	return nil, errors.New("got error")
+}
+
+func Test_ShutdownServeFailed(t *testing.T) {
+	defer gostub.Stub(&listen, func(network, address string) (net.Listener, error) {
+		lis, err := net.Listen(network, address)
+		return failedListener{Listener: lis}, err
+	}).Reset()
+	done := make(chan error)
+	s := NewShutdown(
+		WithQuit(make(chan os.Signal)),
+		WithDone(done),
+		WithHTTPServer(http.NotFoundHandler(), config.Server{Port: "0", ServerTimeout: time.Second}),
+	)
+	require.NoError(t, s.Start())
+
+	go s.Shutdown()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("serve failure not trigger shutdown")
+	}
+	require.False(t, s.Ready())
+}
@@ -23,6 +23,10 @@ const (
 	MaxPartition = 32
 )
 
+var (
+	validate = validator.New()
+)
+
 func BatchMutate[T comparable](
 	ctx context.Context,
 	session spannerSession.ISession,
@@ -177,7 +181,7 @@ func ValidListArgument(row, page uint64) error {
 		Row:  row,
 		Page: page,
 	}
-	if err := validator.New().Struct(&validStruct); err != nil {
+	if err := validate.Struct(&validStruct); err != nil {
 		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
 	}
 	return nil
@@ -5,24 +5,48 @@ import (
 	"github.com/justdomepaul/toolbox/errorhandler"
 )
 
+// ParseUUID method
+// panics with *errorhandler.ErrExecute when input invalid, see ParseUUIDWithError
 func ParseUUID(input string) []byte {
+	result, err := ParseUUIDWithError(input)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// ParseUUIDWithError method
+// returns *errorhandler.ErrExecute when input invalid
+func ParseUUIDWithError(input string) ([]byte, error) {
 	if input != "" {
 		uid, err := uuid.Parse(input)
 		if err != nil {
-			panic(errorhandler.NewErrExecute(err))
+			return nil, errorhandler.NewErrExecute(err)
 		}
-		return uid[:]
+		return uid[:], nil
 	}
-	return []byte("")
+	return []byte(""), nil
 }
 
+// FromUUID method
+// panics with *errorhandler.ErrExecute when input invalid, see FromUUIDWithError
 func FromUUID(input []byte) string {
+	result, err := FromUUIDWithError(input)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// FromUUIDWithError method
+// returns *errorhandler.ErrExecute when input invalid
+func FromUUIDWithError(input []byte) (string, error) {
 	if len(input) > 0 {
 		uid, err := uuid.FromBytes(input)
 		if err != nil {
-			panic(errorhandler.NewErrExecute(err))
+			return "", errorhandler.NewErrExecute(err)
 		}
-		return uid.String()
+		return uid.String(), nil
 	}
-	return ""
+	return "", nil
 }
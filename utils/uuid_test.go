@@ -2,6 +2,7 @@ package utils
 
 import (
 	"github.com/google/uuid"
+	"github.com/justdomepaul/toolbox/errorhandler"
 	"github.com/stretchr/testify/suite"
 	"testing"
 )
@@ -18,13 +19,13 @@ func (suite *UUIDSuite) TestParseUUID() {
 	})
 }
 
-func (suite *UUIDSuite) TestParseUUIDEmpty() {
+func (suite *UUIDSuite) TestParseUUIDWithErrormpty() {
 	suite.NotPanics(func() {
 		suite.Empty(ParseUUID(""))
 	})
 }
 
-func (suite *UUIDSuite) TestParseUUIDError() {
+func (suite *UUIDSuite) TestParseUUIDWithErrorrror() {
 	suite.Panics(func() {
 		ParseUUID("testUUID")
 	})
@@ -38,18 +39,48 @@ func (suite *UUIDSuite) TestFromUUID() {
 	})
 }
 
-func (suite *UUIDSuite) TestFromUUIDEmpty() {
+func (suite *UUIDSuite) TestFromUUIDWithErrormpty() {
 	suite.NotPanics(func() {
 		suite.Equal("", FromUUID([]byte(nil)))
 	})
 }
 
-func (suite *UUIDSuite) TestFromUUIDError() {
+func (suite *UUIDSuite) TestFromUUIDWithErrorrror() {
 	suite.Panics(func() {
 		FromUUID([]byte("testUUID"))
 	})
 }
 
+func (suite *UUIDSuite) TestParseUUIDWithError() {
+	uid, err := uuid.NewRandom()
+	suite.NoError(err)
+	result, err := ParseUUIDWithError(uid.String())
+	suite.NoError(err)
+	suite.Equal(uid[:], result)
+
+	result, err = ParseUUIDWithError("")
+	suite.NoError(err)
+	suite.Empty(result)
+
+	_, err = ParseUUIDWithError("testUUID")
+	suite.IsType(&errorhandler.ErrExecute{}, err)
+}
+
+func (suite *UUIDSuite) TestFromUUIDWithError() {
+	uid, err := uuid.NewRandom()
+	suite.NoError(err)
+	result, err := FromUUIDWithError(uid[:])
+	suite.NoError(err)
+	suite.Equal(uid.String(), result)
+
+	result, err = FromUUIDWithError(nil)
+	suite.NoError(err)
+	suite.Empty(result)
+
+	_, err = FromUUIDWithError([]byte("testUUID"))
+	suite.IsType(&errorhandler.ErrExecute{}, err)
+}
+
 func TestUUIDSuite(t *testing.T) {
 	suite.Run(t, new(UUIDSuite))
 }
@@ -0,0 +1,97 @@
+package zap
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/justdomepaul/toolbox/definition"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type loggerKey struct{}
+
+// WithContext method
+// stores logger into ctx, retrieved by FromContext
+func WithContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext method
+// logger stored by WithContext or Logger, with request_id, trace_id, span_id and client_id of ctx,
+// *gin.Context is accepted when gin.Engine ContextWithFallback enabled
+func FromContext(ctx context.Context) *zap.Logger {
+	logger, ok := ctx.Value(loggerKey{}).(*zap.Logger)
+	if !ok || logger == nil {
+		logger = Logger
+	}
+	return logger.With(ContextFields(ctx)...)
+}
+
+// ContextFields method
+// request ID set by WithRequestID, span context of OpenTelemetry, client ID of definition.AuthorizationID
+func ContextFields(ctx context.Context) []zap.Field {
+	fields := make([]zap.Field, 0, 4)
+	if id := RequestID(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", spanContext.TraceID().String()),
+			zap.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	if id := ClientID(ctx); id != "" {
+		fields = append(fields, zap.String("client_id", id))
+	}
+	return fields
+}
+
+// WithRequestID method
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, definition.RequestIDKey, id)
+}
+
+// NewRequestID method
+// incoming request ID when printable and at most 128 bytes, new UUID otherwise
+func NewRequestID(incoming string) string {
+	if incoming != "" && len(incoming) <= 128 && printable([]byte(incoming)) {
+		return incoming
+	}
+	return uuid.NewString()
+}
+
+// RequestID method
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(definition.RequestIDKey).(string)
+	return id
+}
+
+// ClientID method
+// authenticated client ID of definition.AuthorizationID, binary UUID is formatted as UUID string
+func ClientID(ctx context.Context) string {
+	switch id := ctx.Value(definition.AuthorizationID).(type) {
+	case string:
+		return id
+	case []byte:
+		return FormatClientID(id)
+	}
+	return ""
+}
+
+// FormatClientID method
+// binary UUID is formatted as UUID string, other IDs as string
+func FormatClientID(id []byte) string {
+	if len(id) == 16 && !printable(id) {
+		if uid, err := uuid.FromBytes(id); err == nil {
+			return uid.String()
+		}
+	}
+	return string(id)
+}
+
+func printable(b []byte) bool {
+	return utf8.Valid(b) && strings.IndexFunc(string(b), func(r rune) bool { return !unicode.IsPrint(r) }) < 0
+}
@@ -0,0 +1,62 @@
+package zap
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/justdomepaul/toolbox/definition"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"strings"
+	"testing"
+)
+
+type ContextSuite struct {
+	suite.Suite
+}
+
+func (suite *ContextSuite) TestFromContext() {
+	core, logs := observer.New(zapcore.DebugLevel)
+	ctx := WithRequestID(WithContext(context.Background(), zap.New(core)), "request-1")
+	ctx = context.WithValue(ctx, definition.AuthorizationID, "client")
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	FromContext(ctx).Info("correlated")
+	suite.Equal(1, logs.Len())
+	suite.Equal(map[string]interface{}{
+		"request_id": "request-1",
+		"trace_id":   "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":    "00f067aa0ba902b7",
+		"client_id":  "client",
+	}, logs.All()[0].ContextMap())
+}
+
+func (suite *ContextSuite) TestFromContextDefault() {
+	suite.Equal(Logger.Core(), FromContext(context.Background()).Core())
+	suite.Empty(ContextFields(context.Background()))
+}
+
+func (suite *ContextSuite) TestClientID() {
+	uid := uuid.New()
+	suite.Equal(uid.String(), ClientID(context.WithValue(context.Background(), definition.AuthorizationID, uid[:])))
+	suite.Equal("client", ClientID(context.WithValue(context.Background(), definition.AuthorizationID, []byte("client"))))
+	suite.Equal("sixteen-byte-str", ClientID(context.WithValue(context.Background(), definition.AuthorizationID, []byte("sixteen-byte-str"))))
+	suite.Equal("", ClientID(context.WithValue(context.Background(), definition.AuthorizationID, 1)))
+	suite.Equal("", ClientID(context.Background()))
+}
+
+func (suite *ContextSuite) TestNewRequestID() {
+	suite.Equal("request-1", NewRequestID("request-1"))
+	for _, incoming := range []string{"", strings.Repeat("a", 129), "line\nbreak"} {
+		_, err := uuid.Parse(NewRequestID(incoming))
+		suite.NoError(err, incoming)
+	}
+}
+
+func TestContextSuite(t *testing.T) {
+	suite.Run(t, new(ContextSuite))
+}
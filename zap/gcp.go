@@ -0,0 +1,60 @@
+package zap
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Google Cloud Logging special fields of structured payload
+const (
+	GCPTraceKey        = "logging.googleapis.com/trace"
+	GCPSpanIDKey       = "logging.googleapis.com/spanId"
+	GCPTraceSampledKey = "logging.googleapis.com/trace_sampled"
+)
+
+// GCPTrace method
+// fields correlating log entry with Cloud Trace, e.g. logger.Info("msg", zap.GCPTrace(projectID, traceID, spanID)...)
+func GCPTrace(projectID, traceID, spanID string) []zap.Field {
+	fields := []zap.Field{zap.String(GCPTraceKey, fmt.Sprintf("projects/%s/traces/%s", projectID, traceID))}
+	if spanID != "" {
+		fields = append(fields, zap.String(GCPSpanIDKey, spanID))
+	}
+	return fields
+}
+
+// gcpEncoderConfig Google Cloud Logging compatible keys, severity and timestamp
+func gcpEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		TimeKey:        "time",
+		LevelKey:       "severity",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		MessageKey:     "message",
+		StacktraceKey:  "stack_trace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    gcpLevelEncoder,
+		EncodeTime:     zapcore.RFC3339NanoTimeEncoder,
+		EncodeDuration: zapcore.SecondsDurationEncoder,
+		EncodeCaller:   zapcore.FullCallerEncoder,
+		EncodeName:     zapcore.FullNameEncoder,
+	}
+}
+
+var gcpSeverities = map[zapcore.Level]string{
+	zapcore.DebugLevel:  "DEBUG",
+	zapcore.InfoLevel:   "INFO",
+	zapcore.WarnLevel:   "WARNING",
+	zapcore.ErrorLevel:  "ERROR",
+	zapcore.DPanicLevel: "CRITICAL",
+	zapcore.PanicLevel:  "ALERT",
+	zapcore.FatalLevel:  "EMERGENCY",
+}
+
+func gcpLevelEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	severity, ok := gcpSeverities[level]
+	if !ok {
+		severity = "DEFAULT"
+	}
+	enc.AppendString(severity)
+}
@@ -0,0 +1,66 @@
+package zap
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"testing"
+	"time"
+)
+
+type GCPSuite struct {
+	suite.Suite
+}
+
+func (suite *GCPSuite) TestGCPEncoder() {
+	encoder, err := newEncoder("gcp")
+	suite.NoError(err)
+	buf, err := encoder.EncodeEntry(zapcore.Entry{
+		Level:      zapcore.WarnLevel,
+		Time:       time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC),
+		LoggerName: "database",
+		Message:    "gcp",
+	}, GCPTrace("project", "trace", "span"))
+	suite.NoError(err)
+
+	entry := make(map[string]interface{})
+	suite.NoError(json.Unmarshal(buf.Bytes(), &entry))
+	suite.Equal("WARNING", entry["severity"])
+	suite.Equal("gcp", entry["message"])
+	suite.Equal("2023-01-02T03:04:05.000000006Z", entry["time"])
+	suite.Equal("database", entry["logger"])
+	suite.Equal("projects/project/traces/trace", entry[GCPTraceKey])
+	suite.Equal("span", entry[GCPSpanIDKey])
+}
+
+func (suite *GCPSuite) TestGCPTrace() {
+	suite.Equal([]zap.Field{zap.String(GCPTraceKey, "projects/project/traces/trace")}, GCPTrace("project", "trace", ""))
+}
+
+func (suite *GCPSuite) TestGCPLevelEncoder() {
+	for level, severity := range map[zapcore.Level]string{
+		zapcore.DebugLevel:  "DEBUG",
+		zapcore.ErrorLevel:  "ERROR",
+		zapcore.DPanicLevel: "CRITICAL",
+		zapcore.FatalLevel:  "EMERGENCY",
+		zapcore.Level(10):   "DEFAULT",
+	} {
+		enc := &sliceArrayEncoder{}
+		gcpLevelEncoder(level, enc)
+		suite.Equal([]string{severity}, enc.items)
+	}
+}
+
+func TestGCPSuite(t *testing.T) {
+	suite.Run(t, new(GCPSuite))
+}
+
+type sliceArrayEncoder struct {
+	zapcore.PrimitiveArrayEncoder
+	items []string
+}
+
+func (s *sliceArrayEncoder) AppendString(v string) {
+	s.items = append(s.items, v)
+}
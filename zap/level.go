@@ -0,0 +1,265 @@
+package zap
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/justdomepaul/toolbox/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Level global atomic level of loggers built by NewLogger and Logger, changeable at runtime
+var Level = defaultLevels.Level
+
+var defaultLevels = NewLevels()
+
+// Levels type
+// atomic level and named overrides of loggers built with it, changeable at runtime
+type Levels struct {
+	Level zap.AtomicLevel
+
+	mu     sync.RWMutex
+	levels map[string]zapcore.Level
+}
+
+// NewLevels method
+// independent of Level, e.g. for logger built by NewLoggerWithLevels
+func NewLevels() *Levels {
+	return &Levels{Level: zap.NewAtomicLevel(), levels: map[string]zapcore.Level{}}
+}
+
+// Named method
+// child logger of Logger for subsystem, e.g. Named("database"), level overridable by SetNamedLevel
+func Named(name string) *zap.Logger {
+	return Logger.Named(name)
+}
+
+// SetNamedLevel method
+// overrides level of Logger named name and its children, e.g. "database" covers "database.redis"
+func SetNamedLevel(name string, level zapcore.Level) {
+	defaultLevels.SetNamedLevel(name, level)
+}
+
+// ResetNamedLevel method
+// removes override, Logger named name follows Level again
+func ResetNamedLevel(name string) {
+	defaultLevels.ResetNamedLevel(name)
+}
+
+// NamedLevels method
+func NamedLevels() map[string]zapcore.Level {
+	return defaultLevels.NamedLevels()
+}
+
+// WatchLevel method
+// applies config.Core levels by ApplyLevel on every watcher change of Core
+func WatchLevel(watcher *config.Watcher) {
+	defaultLevels.Watch(watcher)
+}
+
+// ApplyLevel method
+// sets Level and replaces named overrides by config.Core, e.g. on config.Watcher change by WatchLevel
+func ApplyLevel(option config.Core) error {
+	return defaultLevels.Apply(option)
+}
+
+// LevelHandler method
+// handler of Level and named overrides, see Levels.Handler
+func LevelHandler() http.Handler {
+	return defaultLevels.Handler()
+}
+
+// SetNamedLevel method
+// overrides level of logger named name and its children, e.g. "database" covers "database.redis"
+func (l *Levels) SetNamedLevel(name string, level zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levels[name] = level
+}
+
+// ResetNamedLevel method
+// removes override, logger named name follows Level again
+func (l *Levels) ResetNamedLevel(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.levels, name)
+}
+
+// NamedLevels method
+func (l *Levels) NamedLevels() map[string]zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	levels := make(map[string]zapcore.Level, len(l.levels))
+	for name, level := range l.levels {
+		levels[name] = level
+	}
+	return levels
+}
+
+// Watch method
+// applies config.Core levels by Apply on every watcher change of Core
+func (l *Levels) Watch(watcher *config.Watcher) {
+	config.Subscribe(watcher, config.NewCore, func(core config.Core) {
+		// core is validated by watcher before notifying
+		_ = l.Apply(core)
+	})
+}
+
+// Apply method
+// sets Level and replaces named overrides by config.Core
+func (l *Levels) Apply(option config.Core) error {
+	level, err := defaultLevel(option)
+	if err != nil {
+		return err
+	}
+	levels := make(map[string]zapcore.Level, len(option.LoggerNamedLevels))
+	for name, text := range option.LoggerNamedLevels {
+		named, err := zapcore.ParseLevel(text)
+		if err != nil {
+			return err
+		}
+		levels[name] = named
+	}
+	l.Level.SetLevel(level)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levels = levels
+	return nil
+}
+
+func defaultLevel(option config.Core) (zapcore.Level, error) {
+	if option.LoggerLevel != "" {
+		return zapcore.ParseLevel(option.LoggerLevel)
+	}
+	if option.LoggerMode == "development" {
+		return zapcore.DebugLevel, nil
+	}
+	return zapcore.InfoLevel, nil
+}
+
+// enabled reports whether level is enabled for logger named name,
+// the nearest named override wins, Level otherwise
+func (l *Levels) enabled(name string, level zapcore.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for name != "" {
+		if named, ok := l.levels[name]; ok {
+			return level >= named
+		}
+		index := strings.LastIndex(name, ".")
+		if index < 0 {
+			break
+		}
+		name = name[:index]
+	}
+	return l.Level.Enabled(level)
+}
+
+// anyEnabled reports whether level is enabled by Level or any named override
+func (l *Levels) anyEnabled(level zapcore.Level) bool {
+	if l.Level.Enabled(level) {
+		return true
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, named := range l.levels {
+		if level >= named {
+			return true
+		}
+	}
+	return false
+}
+
+// levelCore filters entries by Levels of entry logger name,
+// wrapped core is expected to enable every level
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (l *Levels) wrap(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: l}
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.levels.anyEnabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.enabled(ent.LoggerName, ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+type levelPayload struct {
+	Name  string            `json:"name,omitempty"`
+	Level string            `json:"level"`
+	Named map[string]string `json:"named,omitempty"`
+}
+
+type errorPayload struct {
+	Error string `json:"error"`
+}
+
+// Handler method
+// GET responds Level and named overrides,
+// PUT {"level":"debug"} sets Level, PUT {"name":"database","level":"debug"} sets named override,
+// PUT {"name":"database","level":""} removes named override
+func (l *Levels) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var payload levelPayload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				writeJSON(w, http.StatusBadRequest, errorPayload{Error: fmt.Sprintf("invalid request body: %v", err)})
+				return
+			}
+			if payload.Name != "" && payload.Level == "" {
+				l.ResetNamedLevel(payload.Name)
+				break
+			}
+			level, err := zapcore.ParseLevel(payload.Level)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, errorPayload{Error: err.Error()})
+				return
+			}
+			if payload.Name != "" {
+				l.SetNamedLevel(payload.Name, level)
+			} else {
+				l.Level.SetLevel(level)
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			writeJSON(w, http.StatusMethodNotAllowed, errorPayload{Error: fmt.Sprintf("method %s not allowed", r.Method)})
+			return
+		}
+		writeJSON(w, http.StatusOK, l.current())
+	})
+}
+
+func (l *Levels) current() levelPayload {
+	payload := levelPayload{Level: l.Level.Level().String()}
+	levels := l.NamedLevels()
+	if len(levels) > 0 {
+		payload.Named = make(map[string]string, len(levels))
+		for name, level := range levels {
+			payload.Named[name] = level.String()
+		}
+	}
+	return payload
+}
+
+func writeJSON(w http.ResponseWriter, code int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,172 @@
+package zap
+
+import (
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type LevelSuite struct {
+	suite.Suite
+}
+
+func (suite *LevelSuite) SetupTest() {
+	suite.NoError(ApplyLevel(config.Core{LoggerMode: "customized"}))
+}
+
+func (suite *LevelSuite) TearDownTest() {
+	suite.NoError(ApplyLevel(config.Core{LoggerMode: "customized"}))
+}
+
+func (suite *LevelSuite) TestApplyLevel() {
+	suite.NoError(ApplyLevel(config.Core{
+		LoggerMode:        "customized",
+		LoggerLevel:       "warn",
+		LoggerNamedLevels: map[string]string{"database": "debug"},
+	}))
+	suite.Equal(zapcore.WarnLevel, Level.Level())
+	suite.Equal(map[string]zapcore.Level{"database": zapcore.DebugLevel}, NamedLevels())
+
+	suite.NoError(ApplyLevel(config.Core{LoggerMode: "development"}))
+	suite.Equal(zapcore.DebugLevel, Level.Level())
+	suite.Empty(NamedLevels())
+}
+
+func (suite *LevelSuite) TestApplyLevelError() {
+	suite.Error(ApplyLevel(config.Core{LoggerLevel: "verbose"}))
+	suite.Error(ApplyLevel(config.Core{LoggerNamedLevels: map[string]string{"database": "verbose"}}))
+	suite.Equal(zapcore.InfoLevel, Level.Level())
+}
+
+func (suite *LevelSuite) TestWatchLevel() {
+	file := filepath.Join(suite.T().TempDir(), "config.yaml")
+	suite.Require().NoError(os.WriteFile(file, []byte("logger_mode: customized\n"), 0o600))
+	watcher, err := config.NewWatcher(config.NewLoader(config.WithFile(file)))
+	suite.Require().NoError(err)
+	WatchLevel(watcher)
+
+	suite.Require().NoError(os.WriteFile(file, []byte("logger_mode: customized\nlogger_level: error\nlogger_named_levels: database:debug\n"), 0o600))
+	suite.NoError(watcher.Reload())
+	suite.Equal(zapcore.ErrorLevel, Level.Level())
+	suite.Equal(map[string]zapcore.Level{"database": zapcore.DebugLevel}, NamedLevels())
+}
+
+func (suite *LevelSuite) TestNamedLevel() {
+	fac, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(defaultLevels.wrap(fac))
+	SetNamedLevel("database", zapcore.DebugLevel)
+	SetNamedLevel("grpc", zapcore.ErrorLevel)
+
+	logger.Debug("root debug")
+	logger.Info("root info")
+	logger.Named("database").Debug("database debug")
+	logger.Named("database").Named("redis").With(zap.String("key", "value")).Debug("redis debug")
+	logger.Named("grpc").Warn("grpc warn")
+	logger.Named("grpc").Error("grpc error")
+
+	messages := make([]string, 0, logs.Len())
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.Message)
+	}
+	suite.Equal([]string{"root info", "database debug", "redis debug", "grpc error"}, messages)
+
+	ResetNamedLevel("database")
+	logger.Named("database").Debug("database debug")
+	suite.Equal(4, logs.Len())
+}
+
+func (suite *LevelSuite) TestNamed() {
+	logger := Named("database")
+	suite.NotNil(logger)
+	suite.True(logger.Core().Enabled(zapcore.InfoLevel))
+	suite.False(logger.Core().Enabled(zapcore.DebugLevel))
+	SetNamedLevel("database", zapcore.DebugLevel)
+	suite.True(logger.Core().Enabled(zapcore.DebugLevel))
+}
+
+func (suite *LevelSuite) TestNewLoggerPresetModes() {
+	for _, mode := range []string{"development", "production"} {
+		logger, err := NewLogger(config.Core{LoggerMode: mode, LoggerLevel: "error", SystemName: "system"})
+		suite.NoError(err)
+		suite.False(logger.Core().Enabled(zapcore.WarnLevel))
+		suite.True(logger.Core().Enabled(zapcore.ErrorLevel))
+	}
+	_, err := NewLogger(config.Core{LoggerMode: "customized", LoggerLevel: "verbose"})
+	suite.Error(err)
+}
+
+func (suite *LevelSuite) TestNewLoggerWithLevels() {
+	levels := NewLevels()
+	logger, err := NewLoggerWithLevels(config.Core{LoggerMode: "production", LoggerLevel: "error", SystemName: "system"}, levels)
+	suite.NoError(err)
+	suite.Equal(zapcore.InfoLevel, Level.Level())
+	suite.Equal(zapcore.ErrorLevel, levels.Level.Level())
+	suite.False(logger.Core().Enabled(zapcore.WarnLevel))
+
+	Level.SetLevel(zapcore.DebugLevel)
+	suite.False(logger.Core().Enabled(zapcore.WarnLevel))
+	levels.Level.SetLevel(zapcore.WarnLevel)
+	suite.True(logger.Core().Enabled(zapcore.WarnLevel))
+
+	levels.SetNamedLevel("database", zapcore.DebugLevel)
+	suite.True(logger.Named("database").Core().Enabled(zapcore.DebugLevel))
+	suite.Empty(NamedLevels())
+
+	w := httptest.NewRecorder()
+	levels.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/loglevel", nil))
+	suite.JSONEq(`{"level":"warn","named":{"database":"debug"}}`, w.Body.String())
+}
+
+func (suite *LevelSuite) TestLevelHandler() {
+	handler := LevelHandler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/loglevel", nil))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"level":"info"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/debug/loglevel", strings.NewReader(`{"level":"error"}`)))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(zapcore.ErrorLevel, Level.Level())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/debug/loglevel", strings.NewReader(`{"name":"database","level":"debug"}`)))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"level":"error","named":{"database":"debug"}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/debug/loglevel", strings.NewReader(`{"name":"database"}`)))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"level":"error"}`, w.Body.String())
+}
+
+func (suite *LevelSuite) TestLevelHandlerError() {
+	handler := LevelHandler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/debug/loglevel", strings.NewReader(`{`)))
+	suite.Equal(http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/debug/loglevel", strings.NewReader(`{"level":"verbose"}`)))
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Equal(zapcore.InfoLevel, Level.Level())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/debug/loglevel", nil))
+	suite.Equal(http.StatusMethodNotAllowed, w.Code)
+	suite.Equal("GET, PUT", w.Header().Get("Allow"))
+}
+
+func TestLevelSuite(t *testing.T) {
+	suite.Run(t, new(LevelSuite))
+}
@@ -1,20 +1,64 @@
 package zap
 
 import (
+	"fmt"
 	"github.com/justdomepaul/toolbox/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"os"
+	"time"
 )
 
+// NewLogger method
+// applies config.Core levels to the process-wide Level and named overrides shared with Logger,
+// LevelHandler and WatchLevel, then builds logger of LoggerMode,
+// use NewLoggerWithLevels for logger with independent levels
 func NewLogger(option config.Core) (*zap.Logger, error) {
+	return NewLoggerWithLevels(option, defaultLevels)
+}
+
+// NewLoggerWithLevels method
+// applies config.Core levels to levels, then builds logger of LoggerMode controlled by levels
+func NewLoggerWithLevels(option config.Core, levels *Levels) (*zap.Logger, error) {
+	if err := levels.Apply(option); err != nil {
+		return nil, err
+	}
 	return map[string]func(...zap.Option) (*zap.Logger, error){
-		"development": zap.NewDevelopment,
-		"production":  zap.NewProduction,
-		"customized":  NewCustomized(option),
+		"development": newFromConfig(zap.NewDevelopmentConfig(), option, levels),
+		"production":  newFromConfig(zap.NewProductionConfig(), option, levels),
+		"customized": func(...zap.Option) (*zap.Logger, error) {
+			return newZap(option, levels)
+		},
 	}[option.LoggerMode]()
 }
 
+// newFromConfig builds by zap preset config, level is controlled by levels
+func newFromConfig(cfg zap.Config, option config.Core, levels *Levels) func(...zap.Option) (*zap.Logger, error) {
+	return func(options ...zap.Option) (*zap.Logger, error) {
+		cfg.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+		// sampler of preset is rebuilt outside masking core
+		sampling := cfg.Sampling
+		cfg.Sampling = nil
+		return cfg.Build(append(options, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			if masker := newMasker(option); masker != nil {
+				core = masker.Core(core)
+			}
+			if sampling != nil {
+				core = zapcore.NewSamplerWithOptions(core, time.Second, sampling.Initial, sampling.Thereafter)
+			}
+			return levels.wrap(core)
+		}))...)
+	}
+}
+
+// newMasker masker of LoggerMaskKeys and MaskPatterns, nil when LoggerMasking disabled
+func newMasker(option config.Core) *Masker {
+	if !option.LoggerMasking {
+		return nil
+	}
+	return NewMasker(option.LoggerMaskKeys, MaskPatterns...)
+}
+
 var (
 	Logger *zap.Logger
 	sugar  *zap.SugaredLogger
@@ -25,25 +69,69 @@ func init() {
 	if systemName == "" {
 		systemName = "anonymous"
 	}
-	Logger, _ = newZap(zapcore.InfoLevel, systemName)
+	Logger, _ = newZap(config.Core{SystemName: systemName}, defaultLevels)
 	sugar = Logger.Sugar()
 }
 
+// NewCustomized method
+// builds logger writing to config.Core LoggerSinks, sampled when LoggerSamplingInitial set
 func NewCustomized(option config.Core) func(...zap.Option) (*zap.Logger, error) {
 	return func(...zap.Option) (*zap.Logger, error) {
-		return newZap(zapcore.InfoLevel, option.SystemName)
+		return newZap(option, defaultLevels)
 	}
 }
 
-func newZap(level zapcore.Level, systemName string) (*zap.Logger, error) {
-	return zap.New(newCore(level), zap.AddCaller(), zap.Fields(zap.String("system", systemName))), nil
+func newZap(option config.Core, levels *Levels) (*zap.Logger, error) {
+	core, err := newCore(option)
+	if err != nil {
+		return nil, err
+	}
+	return zap.New(levels.wrap(core), zap.AddCaller(), zap.Fields(zap.String("system", option.SystemName))), nil
+}
+
+func newCore(option config.Core) (zapcore.Core, error) {
+	sinks := option.LoggerSinks
+	if len(sinks) == 0 {
+		sinks = []string{"stdout"}
+	}
+	masker := newMasker(option)
+	cores := make([]zapcore.Core, 0, len(sinks))
+	for _, raw := range sinks {
+		s, err := openSink(raw, option)
+		if err != nil {
+			return nil, err
+		}
+		encoder, err := newEncoder(s.encoding)
+		if err != nil {
+			return nil, err
+		}
+		core := zapcore.NewCore(encoder, s.writer, zapcore.DebugLevel)
+		if masker != nil {
+			core = masker.Core(core)
+		}
+		cores = append(cores, core)
+	}
+	core := zapcore.NewTee(cores...)
+	if option.LoggerSamplingInitial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, option.LoggerSamplingTick, option.LoggerSamplingInitial, option.LoggerSamplingThereafter)
+	}
+	return core, nil
 }
 
-func newCore(level zapcore.Level) zapcore.Core {
-	atomicLevel := zap.NewAtomicLevel()
-	atomicLevel.SetLevel(level)
+func newEncoder(encoding string) (zapcore.Encoder, error) {
+	switch encoding {
+	case "", "json":
+		return zapcore.NewJSONEncoder(encoderConfig()), nil
+	case "console":
+		return zapcore.NewConsoleEncoder(encoderConfig()), nil
+	case "gcp":
+		return zapcore.NewJSONEncoder(gcpEncoderConfig()), nil
+	}
+	return nil, fmt.Errorf("unsupported logger encoding: %s", encoding)
+}
 
-	encoderConfig := zapcore.EncoderConfig{
+func encoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
 		TimeKey:        "time",
 		LevelKey:       "level",
 		NameKey:        "logger",
@@ -57,21 +145,20 @@ func newCore(level zapcore.Level) zapcore.Core {
 		EncodeCaller:   zapcore.FullCallerEncoder,
 		EncodeName:     zapcore.FullNameEncoder,
 	}
-	return zapcore.NewCore(
-		zapcore.NewJSONEncoder(encoderConfig),
-		zapcore.NewMultiWriteSyncer(zapcore.AddSync(os.Stdout)),
-		atomicLevel,
-	)
 }
 
+// SugarInfo method
+// args are space separated, e.g. SugarInfo("user", id) logs "user 1"
 func SugarInfo(args ...interface{}) {
-	sugar.Info(args)
+	sugar.Infoln(args...)
 }
 
+// SugarWarn method
 func SugarWarn(args ...interface{}) {
-	sugar.Warn(args)
+	sugar.Warnln(args...)
 }
 
+// SugarError method
 func SugarError(args ...interface{}) {
-	sugar.Error(args)
+	sugar.Errorln(args...)
 }
@@ -51,7 +51,7 @@ func (suite *LoggerSuite) TestSugarInfo() {
 		})
 		require.Equal(suite.T(), 1, logs.Len(), "Expected only one log entry to be written.")
 		suite.T().Log(logs.AllUntimed()[0])
-		suite.Equal("[{Max}]", logs.All()[0].Message)
+		suite.Equal("{Max}", logs.All()[0].Message)
 	})
 }
 
@@ -65,7 +65,7 @@ func (suite *LoggerSuite) TestSugarWarn() {
 		})
 		require.Equal(suite.T(), 1, logs.Len(), "Expected only one log entry to be written.")
 		suite.T().Log(logs.AllUntimed()[0])
-		suite.Equal("[{Max}]", logs.All()[0].Message)
+		suite.Equal("{Max}", logs.All()[0].Message)
 	})
 }
 
@@ -79,7 +79,20 @@ func (suite *LoggerSuite) TestSugarError() {
 		})
 		require.Equal(suite.T(), 1, logs.Len(), "Expected only one log entry to be written.")
 		suite.T().Log(logs.AllUntimed()[0])
-		suite.Equal("[{Max}]", logs.All()[0].Message)
+		suite.Equal("{Max}", logs.All()[0].Message)
+	})
+}
+
+func (suite *LoggerSuite) TestSugarMultipleArgs() {
+	withSugar(suite.T(), zap.DebugLevel, nil, func(log *zap.SugaredLogger, logs *observer.ObservedLogs) {
+		sugar = log
+		SugarInfo("user", 1, "login")
+		SugarWarn("a", "b")
+		SugarError("error:", struct{ Code int }{Code: 500})
+		require.Equal(suite.T(), 3, logs.Len())
+		suite.Equal("user 1 login", logs.All()[0].Message)
+		suite.Equal("a b", logs.All()[1].Message)
+		suite.Equal("error: {500}", logs.All()[2].Message)
 	})
 }
 
@@ -0,0 +1,323 @@
+package zap
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/justdomepaul/toolbox/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Mask replaces sensitive value
+const Mask = config.SecretMask
+
+var (
+	// JWTPattern matches JWS compact serialization
+	JWTPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	// EmailPattern matches email address
+	EmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// DefaultMaskKeys default sensitive keys of config.Core LoggerMaskKeys
+	DefaultMaskKeys = []string{"authorization", "password", "secret", "token"}
+	// MaskPatterns scrubbed from message and string values of loggers built by config.Core with LoggerMasking
+	MaskPatterns = []*regexp.Regexp{JWTPattern, EmailPattern}
+)
+
+// Unmasked interface
+// object fields implementing it are written as is by Masker, e.g. audit.Event
+type Unmasked interface {
+	zapcore.ObjectMarshaler
+	Unmasked()
+}
+
+// Masker type
+// masks values of sensitive keys and scrubs patterns from message and string values
+type Masker struct {
+	keys     []string
+	patterns []*regexp.Regexp
+}
+
+// NewMasker method
+// keys are matched case-insensitively as substring, e.g. "token" matches "access_token"
+func NewMasker(keys []string, patterns ...*regexp.Regexp) *Masker {
+	m := &Masker{patterns: patterns}
+	for _, key := range keys {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			m.keys = append(m.keys, key)
+		}
+	}
+	return m
+}
+
+// Sensitive method
+func (m *Masker) Sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range m.keys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scrub method
+func (m *Masker) Scrub(value string) string {
+	for _, pattern := range m.patterns {
+		value = pattern.ReplaceAllString(value, Mask)
+	}
+	return value
+}
+
+// Field method
+func (m *Masker) Field(field zapcore.Field) zapcore.Field {
+	if field.Type == zapcore.SkipType || field.Type == zapcore.NamespaceType {
+		return field
+	}
+	if _, ok := field.Interface.(Unmasked); ok && field.Type == zapcore.ObjectMarshalerType {
+		return field
+	}
+	if m.Sensitive(field.Key) {
+		return zap.String(field.Key, Mask)
+	}
+	switch field.Type {
+	case zapcore.StringType:
+		field.String = m.Scrub(field.String)
+	case zapcore.ByteStringType:
+		field.Interface = []byte(m.Scrub(string(field.Interface.([]byte))))
+	case zapcore.StringerType:
+		return zap.String(field.Key, m.Scrub(stringer(field.Interface)))
+	case zapcore.ErrorType:
+		if err, ok := field.Interface.(error); ok && err != nil {
+			field.Interface = maskedError{error: err, masker: m}
+		}
+	case zapcore.ObjectMarshalerType:
+		if _, ok := field.Interface.(json.Marshaler); !ok {
+			field.Interface = maskedObject{ObjectMarshaler: field.Interface.(zapcore.ObjectMarshaler), masker: m}
+			return field
+		}
+		return m.reflected(field)
+	case zapcore.ReflectType:
+		return m.reflected(field)
+	}
+	return field
+}
+
+// reflected masks JSON representation of value
+func (m *Masker) reflected(field zapcore.Field) zapcore.Field {
+	raw, err := json.Marshal(field.Interface)
+	if err != nil {
+		return field
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return field
+	}
+	return zap.Reflect(field.Key, m.value(value))
+}
+
+func (m *Masker) value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return m.Scrub(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = m.value(v[i])
+		}
+	case map[string]interface{}:
+		for key := range v {
+			if m.Sensitive(key) {
+				v[key] = Mask
+				continue
+			}
+			v[key] = m.value(v[key])
+		}
+	}
+	return value
+}
+
+func (m *Masker) fields(fields []zapcore.Field) []zapcore.Field {
+	masked := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		masked[i] = m.Field(field)
+	}
+	return masked
+}
+
+// Core method
+// wraps leaf core, entries are masked before written
+func (m *Masker) Core(core zapcore.Core) zapcore.Core {
+	return &maskCore{Core: core, masker: m}
+}
+
+func stringer(v interface{}) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = fmt.Sprintf("PANIC=%v", r)
+		}
+	}()
+	return v.(fmt.Stringer).String()
+}
+
+type maskCore struct {
+	zapcore.Core
+	masker *Masker
+}
+
+func (c *maskCore) With(fields []zapcore.Field) zapcore.Core {
+	return &maskCore{Core: c.Core.With(c.masker.fields(fields)), masker: c.masker}
+}
+
+func (c *maskCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *maskCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.masker.Scrub(ent.Message)
+	return c.Core.Write(ent, c.masker.fields(fields))
+}
+
+// maskedError scrubs message and verbose format of error
+type maskedError struct {
+	error
+	masker *Masker
+}
+
+func (e maskedError) Error() string {
+	return e.masker.Scrub(e.error.Error())
+}
+
+func (e maskedError) Format(s fmt.State, verb rune) {
+	format := "%v"
+	if verb == 'v' && s.Flag('+') {
+		format = "%+v"
+	}
+	_, _ = fmt.Fprint(s, e.masker.Scrub(fmt.Sprintf(format, e.error)))
+}
+
+// maskedObject masks keys and values added by ObjectMarshaler
+type maskedObject struct {
+	zapcore.ObjectMarshaler
+	masker *Masker
+}
+
+func (o maskedObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return o.ObjectMarshaler.MarshalLogObject(maskEncoder{ObjectEncoder: enc, masker: o.masker})
+}
+
+type maskEncoder struct {
+	zapcore.ObjectEncoder
+	masker *Masker
+}
+
+func (e maskEncoder) AddString(key, value string) {
+	if e.masker.Sensitive(key) {
+		value = Mask
+	}
+	e.ObjectEncoder.AddString(key, e.masker.Scrub(value))
+}
+
+func (e maskEncoder) AddByteString(key string, value []byte) {
+	e.AddString(key, string(value))
+}
+
+func (e maskEncoder) AddObject(key string, marshaler zapcore.ObjectMarshaler) error {
+	if e.masker.Sensitive(key) {
+		e.ObjectEncoder.AddString(key, Mask)
+		return nil
+	}
+	return e.ObjectEncoder.AddObject(key, maskedObject{ObjectMarshaler: marshaler, masker: e.masker})
+}
+
+func (e maskEncoder) AddReflected(key string, value interface{}) error {
+	field := e.masker.Field(zap.Reflect(key, value))
+	if field.Type == zapcore.StringType {
+		e.ObjectEncoder.AddString(key, field.String)
+		return nil
+	}
+	return e.ObjectEncoder.AddReflected(key, field.Interface)
+}
+
+// Masked method
+// field of struct value, `secret:"true"` fields are masked, e.g. logger.Info("login", zap.Masked("user", user))
+func Masked(key string, value interface{}) zap.Field {
+	return zap.Object(key, secretObject{value: value})
+}
+
+type secretObject struct {
+	value interface{}
+}
+
+func (o secretObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	v := reflect.ValueOf(o.value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return enc.AddReflected("value", v.Interface())
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		if field.Tag.Get("secret") == "true" {
+			if !value.IsZero() {
+				enc.AddString(name, Mask)
+			}
+			continue
+		}
+		if err := addValue(enc, name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addValue(enc zapcore.ObjectEncoder, name string, value reflect.Value) error {
+	if value.Type() == reflect.TypeOf(time.Time{}) {
+		enc.AddTime(name, value.Interface().(time.Time))
+		return nil
+	}
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		enc.AddDuration(name, time.Duration(value.Int()))
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		enc.AddString(name, value.String())
+	case reflect.Bool:
+		enc.AddBool(name, value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		enc.AddInt64(name, value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		enc.AddUint64(name, value.Uint())
+	case reflect.Float32, reflect.Float64:
+		enc.AddFloat64(name, value.Float())
+	case reflect.Struct:
+		return enc.AddObject(name, secretObject{value: value.Interface()})
+	case reflect.Ptr:
+		if value.IsNil() {
+			return enc.AddReflected(name, nil)
+		}
+		return addValue(enc, name, value.Elem())
+	default:
+		return enc.AddReflected(name, value.Interface())
+	}
+	return nil
+}
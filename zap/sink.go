@@ -0,0 +1,80 @@
+package zap
+
+import (
+	"fmt"
+	"github.com/justdomepaul/toolbox/config"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"time"
+)
+
+var (
+	dial = net.Dial
+)
+
+type sink struct {
+	writer   zapcore.WriteSyncer
+	encoding string
+}
+
+// openSink opens output of LoggerSinks item:
+// stdout, stderr, file:///var/log/app.log (rotated by LoggerFile* limits), udp://host:514 (one entry per datagram),
+// encoding is ?encoding query or LoggerEncoding
+func openSink(raw string, option config.Core) (sink, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return sink{}, fmt.Errorf("invalid logger sink %q: %w", raw, err)
+	}
+	s := sink{encoding: option.LoggerEncoding}
+	if encoding := u.Query().Get("encoding"); encoding != "" {
+		s.encoding = encoding
+	}
+
+	switch u.Scheme {
+	case "":
+		switch u.Path {
+		case "stdout":
+			s.writer = zapcore.Lock(os.Stdout)
+		case "stderr":
+			s.writer = zapcore.Lock(os.Stderr)
+		default:
+			return sink{}, fmt.Errorf("invalid logger sink %q", raw)
+		}
+	case "file":
+		path := u.Path
+		if path == "" {
+			path = u.Opaque
+		}
+		if path == "" {
+			return sink{}, fmt.Errorf("invalid logger sink %q: file path is required", raw)
+		}
+		s.writer = zapcore.AddSync(&lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    option.LoggerFileMaxSizeMB,
+			MaxAge:     days(option.LoggerFileMaxAge),
+			MaxBackups: option.LoggerFileMaxBackups,
+			Compress:   option.LoggerFileCompress,
+		})
+	case "udp":
+		conn, err := dial("udp", u.Host)
+		if err != nil {
+			return sink{}, fmt.Errorf("open logger sink %q: %w", raw, err)
+		}
+		s.writer = zapcore.AddSync(conn)
+	default:
+		return sink{}, fmt.Errorf("unsupported logger sink %q", raw)
+	}
+	return s, nil
+}
+
+// days rounds up, lumberjack keeps age in days
+func days(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Hours() / 24))
+}
@@ -0,0 +1,132 @@
+package zap
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type SinkSuite struct {
+	suite.Suite
+	option config.Core
+}
+
+func (suite *SinkSuite) SetupTest() {
+	suite.option = config.Core{
+		LoggerMode:          "customized",
+		LoggerEncoding:      "json",
+		SystemName:          "system",
+		LoggerFileMaxSizeMB: 1,
+	}
+}
+
+func (suite *SinkSuite) TestOpenSinkStd() {
+	s, err := openSink("stdout", suite.option)
+	suite.NoError(err)
+	suite.Equal("json", s.encoding)
+	s, err = openSink("stderr?encoding=console", suite.option)
+	suite.NoError(err)
+	suite.Equal("console", s.encoding)
+}
+
+func (suite *SinkSuite) TestOpenSinkError() {
+	for _, raw := range []string{"stdin", "file://", "tcp://localhost:514", "%zz"} {
+		_, err := openSink(raw, suite.option)
+		suite.Error(err, raw)
+	}
+	stubs := gostub.Stub(&dial, func(network, address string) (net.Conn, error) {
+		return nil, errors.New("dial error")
+	})
+	defer stubs.Reset()
+	_, err := openSink("udp://localhost:514", suite.option)
+	suite.ErrorContains(err, "dial error")
+}
+
+func (suite *SinkSuite) TestFileSink() {
+	path := filepath.Join(suite.T().TempDir(), "app.log")
+	suite.option.LoggerSinks = []string{"file://" + path, "file:" + path + ".console?encoding=console"}
+	logger, err := NewCustomized(suite.option)()
+	suite.NoError(err)
+	logger.Info("file sink", zap.String("key", "value"))
+	suite.NoError(logger.Sync())
+
+	raw, err := os.ReadFile(path)
+	suite.NoError(err)
+	entry := make(map[string]interface{})
+	suite.NoError(json.Unmarshal(raw, &entry))
+	suite.Equal("info", entry["level"])
+	suite.Equal("file sink", entry["msg"])
+	suite.Equal("value", entry["key"])
+	suite.Equal("system", entry["system"])
+
+	raw, err = os.ReadFile(path + ".console")
+	suite.NoError(err)
+	suite.Contains(string(raw), "\tinfo\t")
+	suite.Contains(string(raw), "file sink")
+}
+
+func (suite *SinkSuite) TestUDPSink() {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer conn.Close()
+	suite.option.LoggerSinks = []string{"udp://" + conn.LocalAddr().String() + "?encoding=gcp"}
+	logger, err := NewCustomized(suite.option)()
+	suite.NoError(err)
+	logger.Warn("udp sink")
+
+	buf := make([]byte, 4096)
+	suite.NoError(conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	suite.NoError(err)
+	entry := make(map[string]interface{})
+	suite.NoError(json.Unmarshal(buf[:n], &entry))
+	suite.Equal("WARNING", entry["severity"])
+	suite.Equal("udp sink", entry["message"])
+}
+
+func (suite *SinkSuite) TestSampling() {
+	path := filepath.Join(suite.T().TempDir(), "app.log")
+	suite.option.LoggerSinks = []string{"file://" + path}
+	suite.option.LoggerSamplingInitial = 2
+	suite.option.LoggerSamplingThereafter = 0
+	suite.option.LoggerSamplingTick = time.Minute
+	logger, err := NewCustomized(suite.option)()
+	suite.NoError(err)
+	for i := 0; i < 10; i++ {
+		logger.Info("sampled")
+	}
+	suite.NoError(logger.Sync())
+
+	raw, err := os.ReadFile(path)
+	suite.NoError(err)
+	suite.Equal(2, strings.Count(string(raw), "sampled"))
+}
+
+func (suite *SinkSuite) TestNewCustomizedError() {
+	suite.option.LoggerSinks = []string{"stdin"}
+	_, err := NewCustomized(suite.option)()
+	suite.Error(err)
+	suite.option.LoggerSinks = []string{"stdout"}
+	suite.option.LoggerEncoding = "xml"
+	_, err = NewCustomized(suite.option)()
+	suite.Error(err)
+}
+
+func (suite *SinkSuite) TestDays() {
+	suite.Equal(0, days(0))
+	suite.Equal(1, days(time.Hour))
+	suite.Equal(2, days(25*time.Hour))
+}
+
+func TestSinkSuite(t *testing.T) {
+	suite.Run(t, new(SinkSuite))
+}